@@ -14,12 +14,36 @@ import (
 
 // VsphereValidatorSpec defines the desired state of a vSphere validator.
 type VsphereValidatorSpec struct {
-	Auth                     VsphereAuth               `json:"auth" yaml:"auth"`
-	Datacenter               string                    `json:"datacenter" yaml:"datacenter"`
-	PrivilegeValidationRules []PrivilegeValidationRule `json:"privilegeValidationRules,omitempty" yaml:"privilegeValidationRules,omitempty"`
-	TagValidationRules       []TagValidationRule       `json:"tagValidationRules,omitempty" yaml:"tagValidationRules,omitempty"`
-	ComputeResourceRules     []ComputeResourceRule     `json:"computeResourceRules,omitempty" yaml:"computeResourceRules,omitempty"`
-	NTPValidationRules       []NTPValidationRule       `json:"ntpValidationRules,omitempty" yaml:"ntpValidationRules,omitempty"`
+	Auth                        VsphereAuth                  `json:"auth" yaml:"auth"`
+	Datacenter                  string                       `json:"datacenter" yaml:"datacenter"`
+	PrivilegeValidationRules    []PrivilegeValidationRule    `json:"privilegeValidationRules,omitempty" yaml:"privilegeValidationRules,omitempty"`
+	TagValidationRules          []TagValidationRule          `json:"tagValidationRules,omitempty" yaml:"tagValidationRules,omitempty"`
+	ComputeResourceRules        []ComputeResourceRule        `json:"computeResourceRules,omitempty" yaml:"computeResourceRules,omitempty"`
+	NTPValidationRules          []NTPValidationRule          `json:"ntpValidationRules,omitempty" yaml:"ntpValidationRules,omitempty"`
+	TopologyValidationRules     []TopologyValidationRule     `json:"topologyValidationRules,omitempty" yaml:"topologyValidationRules,omitempty"`
+	HostDNSValidationRules      []HostDNSValidationRule      `json:"hostDNSValidationRules,omitempty" yaml:"hostDNSValidationRules,omitempty"`
+	HostNetworkRules            []HostNetworkRule            `json:"hostNetworkRules,omitempty" yaml:"hostNetworkRules,omitempty"`
+	IPPoolValidationRules       []IPPoolValidationRule       `json:"ipPoolValidationRules,omitempty" yaml:"ipPoolValidationRules,omitempty"`
+	FolderValidationRules       []FolderValidationRule       `json:"folderValidationRules,omitempty" yaml:"folderValidationRules,omitempty"`
+	ResourcePoolValidationRules []ResourcePoolValidationRule `json:"resourcePoolValidationRules,omitempty" yaml:"resourcePoolValidationRules,omitempty"`
+	DRSRuleValidationRules      []DRSRuleValidationRule      `json:"drsRuleValidationRules,omitempty" yaml:"drsRuleValidationRules,omitempty"`
+	VSANValidationRules         []VSANValidationRule         `json:"vsanValidationRules,omitempty" yaml:"vsanValidationRules,omitempty"`
+	CertificateValidationRules  []CertificateValidationRule  `json:"certificateValidationRules,omitempty" yaml:"certificateValidationRules,omitempty"`
+	LicenseValidationRules      []LicenseValidationRule      `json:"licenseValidationRules,omitempty" yaml:"licenseValidationRules,omitempty"`
+	SupervisorValidationRules   []SupervisorValidationRule   `json:"supervisorValidationRules,omitempty" yaml:"supervisorValidationRules,omitempty"`
+	NSXSegmentValidationRules   []NSXSegmentValidationRule   `json:"nsxSegmentValidationRules,omitempty" yaml:"nsxSegmentValidationRules,omitempty"`
+
+	// WatchInventory enables a long-lived watch of the vCenter inventory that triggers re-validation
+	// as soon as watched hosts, clusters, datastores, networks, resource pools, permissions or roles change.
+	WatchInventory bool `json:"watchInventory,omitempty" yaml:"watchInventory,omitempty"`
+
+	// WatchEvents enables tailing the vCenter event stream for events, e.g., permission changes, role edits,
+	// tag detaches and host disconnects, that trigger re-validation of the rules they could affect.
+	WatchEvents bool `json:"watchEvents,omitempty" yaml:"watchEvents,omitempty"`
+
+	// AttachTriggeringEvents attaches the vCenter events that triggered re-validation, including the user that
+	// caused them and when, to the failures of the rules they could affect. Requires WatchEvents.
+	AttachTriggeringEvents bool `json:"attachTriggeringEvents,omitempty" yaml:"attachTriggeringEvents,omitempty"`
 }
 
 var _ plugins.PluginSpec = (*VsphereValidatorSpec)(nil)
@@ -32,7 +56,43 @@ func (s VsphereValidatorSpec) PluginCode() string {
 // ResultCount returns the number of validation results expected for a VsphereValidatorSpec.
 func (s VsphereValidatorSpec) ResultCount() int {
 	return len(s.PrivilegeValidationRules) + len(s.ComputeResourceRules) +
-		len(s.TagValidationRules) + len(s.NTPValidationRules)
+		len(s.TagValidationRules) + len(s.NTPValidationRules) + len(s.TopologyValidationRules) +
+		len(s.HostDNSValidationRules) + len(s.HostNetworkRules) + len(s.IPPoolValidationRules) +
+		len(s.FolderValidationRules) + len(s.ResourcePoolValidationRules) + len(s.DRSRuleValidationRules) +
+		len(s.VSANValidationRules) + len(s.CertificateValidationRules) + len(s.LicenseValidationRules) +
+		len(s.SupervisorValidationRules) + len(s.NSXSegmentValidationRules)
+}
+
+// ValidationTypes returns the validation types of the rules in a VsphereValidatorSpec.
+func (s VsphereValidatorSpec) ValidationTypes() []string {
+	counts := []struct {
+		validationType string
+		rules          int
+	}{
+		{constants.ValidationTypePrivileges, len(s.PrivilegeValidationRules)},
+		{constants.ValidationTypeTag, len(s.TagValidationRules)},
+		{constants.ValidationTypeComputeResources, len(s.ComputeResourceRules)},
+		{constants.ValidationTypeNTP, len(s.NTPValidationRules)},
+		{constants.ValidationTypeTopology, len(s.TopologyValidationRules)},
+		{constants.ValidationTypeHostDNS, len(s.HostDNSValidationRules)},
+		{constants.ValidationTypeHostNetwork, len(s.HostNetworkRules)},
+		{constants.ValidationTypeIPPool, len(s.IPPoolValidationRules)},
+		{constants.ValidationTypeFolder, len(s.FolderValidationRules)},
+		{constants.ValidationTypeResourcePool, len(s.ResourcePoolValidationRules)},
+		{constants.ValidationTypeDRSRule, len(s.DRSRuleValidationRules)},
+		{constants.ValidationTypeVSAN, len(s.VSANValidationRules)},
+		{constants.ValidationTypeCertificate, len(s.CertificateValidationRules)},
+		{constants.ValidationTypeLicense, len(s.LicenseValidationRules)},
+		{constants.ValidationTypeSupervisor, len(s.SupervisorValidationRules)},
+		{constants.ValidationTypeNSXSegment, len(s.NSXSegmentValidationRules)},
+	}
+	validationTypes := make([]string, 0)
+	for _, c := range counts {
+		if c.rules > 0 {
+			validationTypes = append(validationTypes, c.validationType)
+		}
+	}
+	return validationTypes
 }
 
 // VsphereAuth defines authentication configuration for a vSphere validator.
@@ -55,7 +115,27 @@ type NTPValidationRule struct {
 	ClusterName string `json:"clusterName,omitempty" yaml:"clusterName,omitempty"`
 
 	// Hosts is the list of vCenter Hosts to validate NTP configuration for.
-	Hosts []string `json:"hosts" yaml:"hosts"`
+	// If empty, all hosts in ClusterName are validated.
+	Hosts []string `json:"hosts,omitempty" yaml:"hosts,omitempty"`
+
+	// Protocol is the time synchronization protocol that the hosts are expected to use. Defaults to ntp.
+	// PTP requires ESXi 7.0 Update 3 or later.
+	// +kubebuilder:validation:Enum=ntp;ptp
+	Protocol string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+
+	// ExpectedServers is the list of NTP servers that the hosts are expected to be configured with.
+	ExpectedServers []string `json:"expectedServers,omitempty" yaml:"expectedServers,omitempty"`
+
+	// ServerMatch controls how each host's NTP servers are compared to ExpectedServers. Defaults to exact.
+	// exact: each host must be configured with exactly the expected servers.
+	// subset: each host must only be configured with servers from the expected servers.
+	// +kubebuilder:validation:Enum=exact;subset
+	ServerMatch string `json:"serverMatch,omitempty" yaml:"serverMatch,omitempty"`
+
+	// MaxClockSkewMilliseconds is the maximum allowed difference between each host's clock and vCenter's clock,
+	// and between the clocks of any two hosts. If zero, clock skew is not validated.
+	// +kubebuilder:validation:Minimum=0
+	MaxClockSkewMilliseconds int `json:"maxClockSkewMilliseconds,omitempty" yaml:"maxClockSkewMilliseconds,omitempty"`
 }
 
 var _ validationrule.Interface = (*NTPValidationRule)(nil)
@@ -123,6 +203,36 @@ type PrivilegeValidationRule struct {
 
 	// Propagation validation configuration for permissions that grant the user privileges on the vCenter entity.
 	Propagation Propagation `json:"propagation,omitempty" yaml:"propagation,omitempty"`
+
+	// Remediation configures the creation or update of a role with the required privileges, and of a permission
+	// that grants it to a principal on the vCenter entity, when the rule fails.
+	Remediation PrivilegeRemediation `json:"remediation,omitempty" yaml:"remediation,omitempty"`
+}
+
+// Remediation contains configuration related to remediating a rule's failures.
+type Remediation struct {
+	// Enabled controls whether the rule's failures are remediated. The validator account must have sufficient
+	// privileges to make the required changes.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// DryRun records the changes that remediation would make in the rule's result without applying them.
+	DryRun bool `json:"dryRun,omitempty" yaml:"dryRun,omitempty"`
+}
+
+// PrivilegeRemediation contains configuration related to remediating a privilege validation rule's failures.
+type PrivilegeRemediation struct {
+	Remediation `json:",inline" yaml:",inline"`
+
+	// RoleName is the name of the role that is created with the rule's privileges, or that the rule's
+	// missing privileges are added to if it exists.
+	RoleName string `json:"roleName,omitempty" yaml:"roleName,omitempty"`
+
+	// Principal is the user or group principal that is granted the role on the vCenter entity.
+	// Principals must be of the format DOMAIN\name, e.g., VSPHERE.LOCAL\k8s-admins.
+	Principal string `json:"principal,omitempty" yaml:"principal,omitempty"`
+
+	// Group indicates whether Principal is a group.
+	Group bool `json:"group,omitempty" yaml:"group,omitempty"`
 }
 
 // Propagation contains configuration related to propagation validation.
@@ -169,8 +279,30 @@ type TagValidationRule struct {
 	// EntityName is the name of the vCenter entity to validate tags on.
 	EntityName string `json:"entityName" yaml:"entityName"`
 
-	// Tag is the tag to validate on the vCenter entity.
+	// Tag is the name of the tag category to validate on the vCenter entity.
 	Tag string `json:"tag" yaml:"tag"`
+
+	// TagName is the name of the tag within the tag category that must be attached to the vCenter entity.
+	// If TagName, TagNamePattern and AllowedTagNames are all empty, any tag in the category satisfies the rule.
+	TagName string `json:"tagName,omitempty" yaml:"tagName,omitempty"`
+
+	// TagNamePattern is a regular expression that the name of the attached tag must match.
+	TagNamePattern string `json:"tagNamePattern,omitempty" yaml:"tagNamePattern,omitempty"`
+
+	// AllowedTagNames is a list of tag names, one of which must be attached to the vCenter entity.
+	AllowedTagNames []string `json:"allowedTagNames,omitempty" yaml:"allowedTagNames,omitempty"`
+
+	// Cardinality is the expected cardinality of the tag category. One of SINGLE or MULTIPLE.
+	// If empty, the tag category's cardinality is not validated.
+	// +kubebuilder:validation:Enum=SINGLE;MULTIPLE
+	Cardinality string `json:"cardinality,omitempty" yaml:"cardinality,omitempty"`
+
+	// Absent asserts that no matching tag is attached to the vCenter entity.
+	Absent bool `json:"absent,omitempty" yaml:"absent,omitempty"`
+
+	// Remediation configures the creation of a missing tag category and tag, and the attachment of the tag to
+	// the vCenter entity, when the rule fails. The tag attached is TagName, or else the first of AllowedTagNames.
+	Remediation Remediation `json:"remediation,omitempty" yaml:"remediation,omitempty"`
 }
 
 var _ validationrule.Interface = (*TagValidationRule)(nil)
@@ -185,6 +317,494 @@ func (r *TagValidationRule) SetName(name string) {
 	r.RuleName = name
 }
 
+// TopologyValidationRule defines a Kubernetes region/zone topology validation rule.
+type TopologyValidationRule struct {
+	validationrule.ManuallyNamed `json:",inline" yaml:",omitempty"`
+
+	// RuleName is the name of the topology validation rule.
+	RuleName string `json:"name" yaml:"name"`
+
+	// Datacenters is the list of datacenters that must carry a region tag.
+	// If empty, every datacenter in the vCenter inventory is validated.
+	Datacenters []string `json:"datacenters,omitempty" yaml:"datacenters,omitempty"`
+
+	// RegionCategory is the tag category used for regions. Defaults to k8s-region.
+	RegionCategory string `json:"regionCategory,omitempty" yaml:"regionCategory,omitempty"`
+
+	// ZoneCategory is the tag category used for zones. Defaults to k8s-zone.
+	ZoneCategory string `json:"zoneCategory,omitempty" yaml:"zoneCategory,omitempty"`
+
+	// ZoneEntityType is the type of the vCenter entity that carries zone tags. One of Cluster or ESXi Host.
+	// Defaults to Cluster.
+	ZoneEntityType string `json:"zoneEntityType,omitempty" yaml:"zoneEntityType,omitempty"`
+
+	// Network is the name of a network that must be available in every zone.
+	Network string `json:"network,omitempty" yaml:"network,omitempty"`
+}
+
+var _ validationrule.Interface = (*TopologyValidationRule)(nil)
+
+// Name returns the name of the topology validation rule.
+func (r TopologyValidationRule) Name() string {
+	return r.RuleName
+}
+
+// SetName sets the name of the topology validation rule.
+func (r *TopologyValidationRule) SetName(name string) {
+	r.RuleName = name
+}
+
+// HostDNSValidationRule defines an ESXi host DNS, hostname and domain validation rule.
+type HostDNSValidationRule struct {
+	validationrule.ManuallyNamed `json:",inline" yaml:",omitempty"`
+
+	// RuleName is the name of the host DNS validation rule.
+	RuleName string `json:"name" yaml:"name"`
+
+	// ClusterName is required when the vCenter Host(s) reside beneath a Cluster in the vCenter object hierarchy.
+	ClusterName string `json:"clusterName,omitempty" yaml:"clusterName,omitempty"`
+
+	// Hosts is the list of vCenter Hosts to validate DNS configuration for.
+	// If empty, all hosts in ClusterName are validated.
+	Hosts []string `json:"hosts,omitempty" yaml:"hosts,omitempty"`
+
+	// ExpectedDNSServers is the list of DNS servers that each host must be configured with, in any order.
+	ExpectedDNSServers []string `json:"expectedDNSServers,omitempty" yaml:"expectedDNSServers,omitempty"`
+
+	// ExpectedSearchDomains is the list of search domains that each host must be configured with, in any order.
+	ExpectedSearchDomains []string `json:"expectedSearchDomains,omitempty" yaml:"expectedSearchDomains,omitempty"`
+
+	// FQDNPattern is a regular expression that each host's fully qualified domain name must match.
+	FQDNPattern string `json:"fqdnPattern,omitempty" yaml:"fqdnPattern,omitempty"`
+
+	// ValidateLookups controls whether forward and reverse lookups of each host's FQDN and management IP addresses
+	// are validated against the host's first configured DNS server.
+	ValidateLookups bool `json:"validateLookups,omitempty" yaml:"validateLookups,omitempty"`
+}
+
+var _ validationrule.Interface = (*HostDNSValidationRule)(nil)
+
+// Name returns the name of the host DNS validation rule.
+func (r HostDNSValidationRule) Name() string {
+	return r.RuleName
+}
+
+// SetName sets the name of the host DNS validation rule.
+func (r *HostDNSValidationRule) SetName(name string) {
+	r.RuleName = name
+}
+
+// HostNetworkRule defines an ESXi host VMkernel adapter and physical NIC validation rule.
+type HostNetworkRule struct {
+	validationrule.ManuallyNamed `json:",inline" yaml:",omitempty"`
+
+	// RuleName is the name of the host network validation rule.
+	RuleName string `json:"name" yaml:"name"`
+
+	// ClusterName is required when the vCenter Host(s) reside beneath a Cluster in the vCenter object hierarchy.
+	ClusterName string `json:"clusterName,omitempty" yaml:"clusterName,omitempty"`
+
+	// Hosts is the list of vCenter Hosts to validate network configuration for.
+	// If empty, all hosts in ClusterName are validated.
+	Hosts []string `json:"hosts,omitempty" yaml:"hosts,omitempty"`
+
+	// VMKernelAdapters is the list of VMkernel adapter requirements that each host must satisfy.
+	VMKernelAdapters []VMKernelAdapterRequirement `json:"vmkernelAdapters,omitempty" yaml:"vmkernelAdapters,omitempty"`
+
+	// PhysicalNICs is the list of physical NIC requirements that each host must satisfy.
+	PhysicalNICs []PhysicalNICRequirement `json:"physicalNICs,omitempty" yaml:"physicalNICs,omitempty"`
+}
+
+var _ validationrule.Interface = (*HostNetworkRule)(nil)
+
+// Name returns the name of the host network validation rule.
+func (r HostNetworkRule) Name() string {
+	return r.RuleName
+}
+
+// SetName sets the name of the host network validation rule.
+func (r *HostNetworkRule) SetName(name string) {
+	r.RuleName = name
+}
+
+// VMKernelAdapterRequirement defines the requirements for the VMkernel adapters enabled for a service.
+// The MTU of the adapters enabled for the service must also be identical across all hosts.
+type VMKernelAdapterRequirement struct {
+	// Service is the service that must be enabled on at least one VMkernel adapter.
+	// +kubebuilder:validation:Enum=management;vmotion;vsan;vSphereProvisioning
+	Service string `json:"service" yaml:"service"`
+
+	// MinMTU is the minimum MTU of the VMkernel adapters enabled for the service.
+	// +kubebuilder:validation:Minimum=0
+	MinMTU int `json:"minMTU,omitempty" yaml:"minMTU,omitempty"`
+
+	// CIDRs is the list of CIDRs that the IP addresses of the VMkernel adapters enabled for the service must be within.
+	CIDRs []string `json:"cidrs,omitempty" yaml:"cidrs,omitempty"`
+}
+
+// PhysicalNICRequirement defines the requirements for a physical NIC.
+type PhysicalNICRequirement struct {
+	// Device is the name of the physical NIC, e.g., vmnic0.
+	Device string `json:"device" yaml:"device"`
+
+	// MinSpeedMb is the minimum link speed of the physical NIC in megabits per second.
+	// The physical NIC's link must always be up.
+	// +kubebuilder:validation:Minimum=0
+	MinSpeedMb int `json:"minSpeedMb,omitempty" yaml:"minSpeedMb,omitempty"`
+}
+
+// IPPoolValidationRule defines a static IP pool validation rule.
+type IPPoolValidationRule struct {
+	validationrule.ManuallyNamed `json:",inline" yaml:",omitempty"`
+
+	// RuleName is the name of the IP pool validation rule.
+	RuleName string `json:"name" yaml:"name"`
+
+	// PortGroup is the name of the port group that VMs using the IP pool will be attached to.
+	PortGroup string `json:"portGroup" yaml:"portGroup"`
+
+	// CIDRs is the list of CIDR ranges that make up the IP pool. The ranges must not overlap.
+	// +kubebuilder:validation:MinItems=1
+	CIDRs []string `json:"cidrs" yaml:"cidrs"`
+
+	// Subnet is the CIDR of the network that the IP pool belongs to.
+	// If empty, each of the IP pool's CIDR ranges is treated as its own subnet.
+	Subnet string `json:"subnet,omitempty" yaml:"subnet,omitempty"`
+
+	// Gateway is the IP address of the network's gateway. It must fall within the subnet.
+	Gateway string `json:"gateway" yaml:"gateway"`
+
+	// MinFreeIPs is the minimum number of addresses in the IP pool that must not be used by the gateway or any VM.
+	// +kubebuilder:validation:Minimum=0
+	MinFreeIPs int `json:"minFreeIPs,omitempty" yaml:"minFreeIPs,omitempty"`
+}
+
+var _ validationrule.Interface = (*IPPoolValidationRule)(nil)
+
+// Name returns the name of the IP pool validation rule.
+func (r IPPoolValidationRule) Name() string {
+	return r.RuleName
+}
+
+// SetName sets the name of the IP pool validation rule.
+func (r *IPPoolValidationRule) SetName(name string) {
+	r.RuleName = name
+}
+
+// FolderValidationRule defines a folder validation rule.
+type FolderValidationRule struct {
+	validationrule.ManuallyNamed `json:",inline" yaml:",omitempty"`
+
+	// RuleName is the name of the folder validation rule.
+	RuleName string `json:"name" yaml:"name"`
+
+	// Path is the path of the folder. Relative paths are resolved beneath the datacenter's root folder of the given Type,
+	// e.g., k8s/workload with Type vm resolves to /{datacenter}/vm/k8s/workload.
+	Path string `json:"path" yaml:"path"`
+
+	// Type is the type of the folder, i.e., the type of objects it may contain. Defaults to vm.
+	// +kubebuilder:validation:Enum=vm;host;datastore;network
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// MaxVMs is the maximum number of VMs that may reside directly within the folder.
+	// If zero, the folder must not contain any VMs. If unset, the number of VMs is not validated.
+	// +kubebuilder:validation:Minimum=0
+	MaxVMs *int `json:"maxVMs,omitempty" yaml:"maxVMs,omitempty"`
+
+	// Privileges is the list of privileges that the user must have on the folder in order to create child objects,
+	// e.g., Folder.Create and VirtualMachine.Inventory.Create. If empty, privileges are not validated.
+	Privileges []string `json:"privileges,omitempty" yaml:"privileges,omitempty"`
+}
+
+var _ validationrule.Interface = (*FolderValidationRule)(nil)
+
+// Name returns the name of the folder validation rule.
+func (r FolderValidationRule) Name() string {
+	return r.RuleName
+}
+
+// SetName sets the name of the folder validation rule.
+func (r *FolderValidationRule) SetName(name string) {
+	r.RuleName = name
+}
+
+// ResourcePoolValidationRule defines a resource pool configuration validation rule.
+type ResourcePoolValidationRule struct {
+	validationrule.ManuallyNamed `json:",inline" yaml:",omitempty"`
+
+	// RuleName is the name of the resource pool validation rule.
+	RuleName string `json:"name" yaml:"name"`
+
+	// ClusterName is the name of the cluster that the resource pool resides beneath.
+	ClusterName string `json:"clusterName" yaml:"clusterName"`
+
+	// ResourcePool is the path of the resource pool beneath the cluster. Nested resource pools are specified as parent/child.
+	ResourcePool string `json:"resourcePool" yaml:"resourcePool"`
+
+	// CPU is the expected CPU allocation of the resource pool, in MHz.
+	CPU *ResourceAllocationRequirement `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+
+	// Memory is the expected memory allocation of the resource pool, in MB.
+	Memory *ResourceAllocationRequirement `json:"memory,omitempty" yaml:"memory,omitempty"`
+}
+
+var _ validationrule.Interface = (*ResourcePoolValidationRule)(nil)
+
+// Name returns the name of the resource pool validation rule.
+func (r ResourcePoolValidationRule) Name() string {
+	return r.RuleName
+}
+
+// SetName sets the name of the resource pool validation rule.
+func (r *ResourcePoolValidationRule) SetName(name string) {
+	r.RuleName = name
+}
+
+// ResourceAllocationRequirement defines the expected allocation of a resource to a resource pool.
+// Unset fields are not validated.
+type ResourceAllocationRequirement struct {
+	// MinReservation is the minimum reservation.
+	// +kubebuilder:validation:Minimum=0
+	MinReservation int64 `json:"minReservation,omitempty" yaml:"minReservation,omitempty"`
+
+	// MinLimit is the minimum limit. The limit must either be unlimited or at least MinLimit.
+	// +kubebuilder:validation:Minimum=0
+	MinLimit int64 `json:"minLimit,omitempty" yaml:"minLimit,omitempty"`
+
+	// SharesLevel is the expected shares level.
+	// +kubebuilder:validation:Enum=low;normal;high;custom
+	SharesLevel string `json:"sharesLevel,omitempty" yaml:"sharesLevel,omitempty"`
+
+	// ExpandableReservation is whether the reservation is expected to be expandable.
+	ExpandableReservation *bool `json:"expandableReservation,omitempty" yaml:"expandableReservation,omitempty"`
+}
+
+// DRSRuleValidationRule defines a DRS rule validation rule.
+// The DRS rule must exist and be enabled, and DRS must be enabled on the cluster.
+type DRSRuleValidationRule struct {
+	validationrule.ManuallyNamed `json:",inline" yaml:",omitempty"`
+
+	// RuleName is the name of the DRS rule validation rule.
+	RuleName string `json:"name" yaml:"name"`
+
+	// ClusterName is the name of the cluster that the DRS rule is configured on.
+	ClusterName string `json:"clusterName" yaml:"clusterName"`
+
+	// DRSRuleName is the name of the DRS rule.
+	DRSRuleName string `json:"drsRuleName" yaml:"drsRuleName"`
+
+	// Type is the expected type of the DRS rule.
+	// +kubebuilder:validation:Enum=vmAffinity;vmAntiAffinity;vmHost
+	Type string `json:"type" yaml:"type"`
+
+	// Mandatory is whether the DRS rule is expected to be mandatory (must) or preferred (should).
+	// If unset, it is not validated.
+	Mandatory *bool `json:"mandatory,omitempty" yaml:"mandatory,omitempty"`
+
+	// VMNamePattern is a glob pattern, e.g., cluster-cp-*. Every VM in the cluster whose name matches the pattern
+	// must be included in the DRS rule, or in its VM group for VM-Host rules.
+	VMNamePattern string `json:"vmNamePattern,omitempty" yaml:"vmNamePattern,omitempty"`
+}
+
+var _ validationrule.Interface = (*DRSRuleValidationRule)(nil)
+
+// Name returns the name of the DRS rule validation rule.
+func (r DRSRuleValidationRule) Name() string {
+	return r.RuleName
+}
+
+// SetName sets the name of the DRS rule validation rule.
+func (r *DRSRuleValidationRule) SetName(name string) {
+	r.RuleName = name
+}
+
+// VSANValidationRule defines a vSAN cluster validation rule.
+// vSAN must be enabled on the cluster, its health tests must pass, and every host in the cluster must contribute disks.
+type VSANValidationRule struct {
+	validationrule.ManuallyNamed `json:",inline" yaml:",omitempty"`
+
+	// RuleName is the name of the vSAN validation rule.
+	RuleName string `json:"name" yaml:"name"`
+
+	// ClusterName is the name of the vSAN cluster.
+	ClusterName string `json:"clusterName" yaml:"clusterName"`
+
+	// IgnoreHealthWarnings controls whether health tests with a yellow (warning) status are ignored.
+	IgnoreHealthWarnings bool `json:"ignoreHealthWarnings,omitempty" yaml:"ignoreHealthWarnings,omitempty"`
+
+	// StoragePolicyName is the name of the storage policy used to compute usable free capacity.
+	// Defaults to the vSAN Default Storage Policy.
+	StoragePolicyName string `json:"storagePolicyName,omitempty" yaml:"storagePolicyName,omitempty"`
+
+	// MinUsableFreeCapacityGB is the minimum free capacity in GB after accounting for the storage policy's
+	// failures to tolerate overhead. If zero, capacity is not validated.
+	// +kubebuilder:validation:Minimum=0
+	MinUsableFreeCapacityGB int `json:"minUsableFreeCapacityGB,omitempty" yaml:"minUsableFreeCapacityGB,omitempty"`
+}
+
+var _ validationrule.Interface = (*VSANValidationRule)(nil)
+
+// Name returns the name of the vSAN validation rule.
+func (r VSANValidationRule) Name() string {
+	return r.RuleName
+}
+
+// SetName sets the name of the vSAN validation rule.
+func (r *VSANValidationRule) SetName(name string) {
+	r.RuleName = name
+}
+
+// CertificateValidationRule defines a vCenter and ESXi host certificate validation rule.
+// The certificate chain presented by the vCenter endpoint and the certificate of each host are validated.
+type CertificateValidationRule struct {
+	validationrule.ManuallyNamed `json:",inline" yaml:",omitempty"`
+
+	// RuleName is the name of the certificate validation rule.
+	RuleName string `json:"name" yaml:"name"`
+
+	// ClusterName is required when the vCenter Host(s) reside beneath a Cluster in the vCenter object hierarchy.
+	ClusterName string `json:"clusterName,omitempty" yaml:"clusterName,omitempty"`
+
+	// Hosts is the list of vCenter Hosts to validate certificates for.
+	// If empty, all hosts in ClusterName are validated.
+	Hosts []string `json:"hosts,omitempty" yaml:"hosts,omitempty"`
+
+	// MinValidDays is the minimum number of days for which each certificate must remain valid.
+	// If zero, certificates must only be unexpired.
+	// +kubebuilder:validation:Minimum=0
+	MinValidDays int `json:"minValidDays,omitempty" yaml:"minValidDays,omitempty"`
+
+	// ExpectedIssuers is the list of issuers that each host certificate must be signed by.
+	// A host certificate's issuer distinguished name must contain one of the issuers, ignoring case,
+	// e.g., "DC=local, DC=vsphere, CN=CA" for the VMware Certificate Authority (VMCA).
+	// If empty, host certificate issuers are not validated.
+	ExpectedIssuers []string `json:"expectedIssuers,omitempty" yaml:"expectedIssuers,omitempty"`
+}
+
+var _ validationrule.Interface = (*CertificateValidationRule)(nil)
+
+// Name returns the name of the certificate validation rule.
+func (r CertificateValidationRule) Name() string {
+	return r.RuleName
+}
+
+// SetName sets the name of the certificate validation rule.
+func (r *CertificateValidationRule) SetName(name string) {
+	r.RuleName = name
+}
+
+// LicenseValidationRule defines an ESXi host license validation rule.
+// The license assigned to each host must include the required features, remain valid, and have free capacity.
+type LicenseValidationRule struct {
+	validationrule.ManuallyNamed `json:",inline" yaml:",omitempty"`
+
+	// RuleName is the name of the license validation rule.
+	RuleName string `json:"name" yaml:"name"`
+
+	// ClusterName is required when the vCenter Host(s) reside beneath a Cluster in the vCenter object hierarchy.
+	ClusterName string `json:"clusterName,omitempty" yaml:"clusterName,omitempty"`
+
+	// Hosts is the list of vCenter Hosts to validate licenses for.
+	// If empty, all hosts in ClusterName are validated.
+	Hosts []string `json:"hosts,omitempty" yaml:"hosts,omitempty"`
+
+	// RequiredFeatures is the list of license feature keys that the license assigned to each host must include,
+	// e.g., "drs", "vsan", "dvs", or "wcp" for Workload Management.
+	RequiredFeatures []string `json:"requiredFeatures,omitempty" yaml:"requiredFeatures,omitempty"`
+
+	// MinValidDays is the minimum number of days for which each license, including evaluation mode licenses,
+	// must remain valid. If zero, licenses must only be unexpired.
+	// +kubebuilder:validation:Minimum=0
+	MinValidDays int `json:"minValidDays,omitempty" yaml:"minValidDays,omitempty"`
+
+	// MinFreeCapacity is the minimum number of unused capacity units, e.g., CPUs or cores, of each license.
+	// Licenses with unlimited capacity are not validated. If zero, capacity is not validated.
+	// +kubebuilder:validation:Minimum=0
+	MinFreeCapacity int `json:"minFreeCapacity,omitempty" yaml:"minFreeCapacity,omitempty"`
+}
+
+var _ validationrule.Interface = (*LicenseValidationRule)(nil)
+
+// Name returns the name of the license validation rule.
+func (r LicenseValidationRule) Name() string {
+	return r.RuleName
+}
+
+// SetName sets the name of the license validation rule.
+func (r *LicenseValidationRule) SetName(name string) {
+	r.RuleName = name
+}
+
+// SupervisorValidationRule defines a vSphere with Tanzu Supervisor and vSphere Namespace validation rule.
+// Workload Management must be enabled on the cluster and its Supervisor must be running and healthy.
+type SupervisorValidationRule struct {
+	validationrule.ManuallyNamed `json:",inline" yaml:",omitempty"`
+
+	// RuleName is the name of the Supervisor validation rule.
+	RuleName string `json:"name" yaml:"name"`
+
+	// ClusterName is the name of the cluster on which Workload Management is enabled.
+	ClusterName string `json:"clusterName" yaml:"clusterName"`
+
+	// NamespaceName is the name of a vSphere Namespace that must exist on the Supervisor.
+	// If empty, no vSphere Namespace is validated.
+	NamespaceName string `json:"namespaceName,omitempty" yaml:"namespaceName,omitempty"`
+
+	// StoragePolicies is the list of storage policies that must be bound to the vSphere Namespace.
+	StoragePolicies []string `json:"storagePolicies,omitempty" yaml:"storagePolicies,omitempty"`
+
+	// VMClasses is the list of VM classes that must be bound to the vSphere Namespace.
+	VMClasses []string `json:"vmClasses,omitempty" yaml:"vmClasses,omitempty"`
+
+	// NodepoolResourceRequirements is the list of node pools that the vSphere Namespace's CPU, memory and storage quotas
+	// must leave room for.
+	NodepoolResourceRequirements []NodepoolResourceRequirement `json:"nodepoolResourceRequirements,omitempty" yaml:"nodepoolResourceRequirements,omitempty"`
+}
+
+var _ validationrule.Interface = (*SupervisorValidationRule)(nil)
+
+// Name returns the name of the Supervisor validation rule.
+func (r SupervisorValidationRule) Name() string {
+	return r.RuleName
+}
+
+// SetName sets the name of the Supervisor validation rule.
+func (r *SupervisorValidationRule) SetName(name string) {
+	r.RuleName = name
+}
+
+// NSXSegmentValidationRule defines an NSX segment validation rule.
+// The segment, either an opaque network or an NSX distributed port group, must be visible in the datacenter
+// and attached to every host in the cluster.
+type NSXSegmentValidationRule struct {
+	validationrule.ManuallyNamed `json:",inline" yaml:",omitempty"`
+
+	// RuleName is the name of the NSX segment validation rule.
+	RuleName string `json:"name" yaml:"name"`
+
+	// ClusterName is the name of the cluster whose hosts must be attached to the segment.
+	ClusterName string `json:"clusterName" yaml:"clusterName"`
+
+	// SegmentName is the name of the NSX segment.
+	SegmentName string `json:"segmentName" yaml:"segmentName"`
+
+	// LogicalSwitchID is the expected NSX logical switch ID of the segment.
+	// If empty, the logical switch ID is not validated.
+	LogicalSwitchID string `json:"logicalSwitchID,omitempty" yaml:"logicalSwitchID,omitempty"`
+}
+
+var _ validationrule.Interface = (*NSXSegmentValidationRule)(nil)
+
+// Name returns the name of the NSX segment validation rule.
+func (r NSXSegmentValidationRule) Name() string {
+	return r.RuleName
+}
+
+// SetName sets the name of the NSX segment validation rule.
+func (r *NSXSegmentValidationRule) SetName(name string) {
+	r.RuleName = name
+}
+
 // NodepoolResourceRequirement defines the resource requirements for a node pool.
 type NodepoolResourceRequirement struct {
 	// Name is the name of the node pool.
@@ -204,7 +824,52 @@ type NodepoolResourceRequirement struct {
 }
 
 // VsphereValidatorStatus defines the observed state of a vSphere validator.
-type VsphereValidatorStatus struct{}
+type VsphereValidatorStatus struct {
+	// RuleHistory records each validation rule's recent outcomes, so that drift between consecutive
+	// validation runs, flapping and regressions can be detected.
+	// +optional
+	RuleHistory []RuleHistory `json:"ruleHistory,omitempty" yaml:"ruleHistory,omitempty"`
+}
+
+// RuleHistory records a validation rule's recent outcomes.
+type RuleHistory struct {
+	// ValidationRule is the name of the validation rule, as reported in the ValidationResult.
+	ValidationRule string `json:"validationRule" yaml:"validationRule"`
+
+	// ValidationType is the type of the validation rule.
+	ValidationType string `json:"validationType" yaml:"validationType"`
+
+	// State is the state of the rule in the latest validation run, either Succeeded or Failed.
+	State string `json:"state" yaml:"state"`
+
+	// LastTransitionTime is when the rule last changed state.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime" yaml:"lastTransitionTime"`
+
+	// FailingSince is when the rule started failing, if it is failing.
+	// +optional
+	FailingSince *metav1.Time `json:"failingSince,omitempty" yaml:"failingSince,omitempty"`
+
+	// Failures are the rule's failures in the latest validation run.
+	// +optional
+	Failures []string `json:"failures,omitempty" yaml:"failures,omitempty"`
+
+	// Drift describes how the rule's state and failures changed since the previous validation run.
+	// +optional
+	Drift []string `json:"drift,omitempty" yaml:"drift,omitempty"`
+
+	// Transitions are the rule's most recent state changes, oldest first.
+	// +optional
+	Transitions []RuleTransition `json:"transitions,omitempty" yaml:"transitions,omitempty"`
+}
+
+// RuleTransition is a change of a validation rule's state.
+type RuleTransition struct {
+	// State is the state that the rule changed to.
+	State string `json:"state" yaml:"state"`
+
+	// Time is when the rule changed state.
+	Time metav1.Time `json:"time" yaml:"time"`
+}
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
@@ -25,6 +25,32 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateValidationRule) DeepCopyInto(out *CertificateValidationRule) {
+	*out = *in
+	out.ManuallyNamed = in.ManuallyNamed
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpectedIssuers != nil {
+		in, out := &in.ExpectedIssuers, &out.ExpectedIssuers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateValidationRule.
+func (in *CertificateValidationRule) DeepCopy() *CertificateValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComputeResourceRule) DeepCopyInto(out *ComputeResourceRule) {
 	*out = *in
@@ -46,6 +72,180 @@ func (in *ComputeResourceRule) DeepCopy() *ComputeResourceRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRSRuleValidationRule) DeepCopyInto(out *DRSRuleValidationRule) {
+	*out = *in
+	out.ManuallyNamed = in.ManuallyNamed
+	if in.Mandatory != nil {
+		in, out := &in.Mandatory, &out.Mandatory
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRSRuleValidationRule.
+func (in *DRSRuleValidationRule) DeepCopy() *DRSRuleValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(DRSRuleValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderValidationRule) DeepCopyInto(out *FolderValidationRule) {
+	*out = *in
+	out.ManuallyNamed = in.ManuallyNamed
+	if in.MaxVMs != nil {
+		in, out := &in.MaxVMs, &out.MaxVMs
+		*out = new(int)
+		**out = **in
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderValidationRule.
+func (in *FolderValidationRule) DeepCopy() *FolderValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostDNSValidationRule) DeepCopyInto(out *HostDNSValidationRule) {
+	*out = *in
+	out.ManuallyNamed = in.ManuallyNamed
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpectedDNSServers != nil {
+		in, out := &in.ExpectedDNSServers, &out.ExpectedDNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpectedSearchDomains != nil {
+		in, out := &in.ExpectedSearchDomains, &out.ExpectedSearchDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostDNSValidationRule.
+func (in *HostDNSValidationRule) DeepCopy() *HostDNSValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(HostDNSValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostNetworkRule) DeepCopyInto(out *HostNetworkRule) {
+	*out = *in
+	out.ManuallyNamed = in.ManuallyNamed
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VMKernelAdapters != nil {
+		in, out := &in.VMKernelAdapters, &out.VMKernelAdapters
+		*out = make([]VMKernelAdapterRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PhysicalNICs != nil {
+		in, out := &in.PhysicalNICs, &out.PhysicalNICs
+		*out = make([]PhysicalNICRequirement, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostNetworkRule.
+func (in *HostNetworkRule) DeepCopy() *HostNetworkRule {
+	if in == nil {
+		return nil
+	}
+	out := new(HostNetworkRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolValidationRule) DeepCopyInto(out *IPPoolValidationRule) {
+	*out = *in
+	out.ManuallyNamed = in.ManuallyNamed
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolValidationRule.
+func (in *IPPoolValidationRule) DeepCopy() *IPPoolValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LicenseValidationRule) DeepCopyInto(out *LicenseValidationRule) {
+	*out = *in
+	out.ManuallyNamed = in.ManuallyNamed
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiredFeatures != nil {
+		in, out := &in.RequiredFeatures, &out.RequiredFeatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseValidationRule.
+func (in *LicenseValidationRule) DeepCopy() *LicenseValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LicenseValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NSXSegmentValidationRule) DeepCopyInto(out *NSXSegmentValidationRule) {
+	*out = *in
+	out.ManuallyNamed = in.ManuallyNamed
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NSXSegmentValidationRule.
+func (in *NSXSegmentValidationRule) DeepCopy() *NSXSegmentValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NSXSegmentValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NTPValidationRule) DeepCopyInto(out *NTPValidationRule) {
 	*out = *in
@@ -55,6 +255,11 @@ func (in *NTPValidationRule) DeepCopyInto(out *NTPValidationRule) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExpectedServers != nil {
+		in, out := &in.ExpectedServers, &out.ExpectedServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NTPValidationRule.
@@ -82,6 +287,37 @@ func (in *NodepoolResourceRequirement) DeepCopy() *NodepoolResourceRequirement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhysicalNICRequirement) DeepCopyInto(out *PhysicalNICRequirement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhysicalNICRequirement.
+func (in *PhysicalNICRequirement) DeepCopy() *PhysicalNICRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(PhysicalNICRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivilegeRemediation) DeepCopyInto(out *PrivilegeRemediation) {
+	*out = *in
+	out.Remediation = in.Remediation
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivilegeRemediation.
+func (in *PrivilegeRemediation) DeepCopy() *PrivilegeRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivilegeRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivilegeValidationRule) DeepCopyInto(out *PrivilegeValidationRule) {
 	*out = *in
@@ -92,6 +328,7 @@ func (in *PrivilegeValidationRule) DeepCopyInto(out *PrivilegeValidationRule) {
 		copy(*out, *in)
 	}
 	in.Propagation.DeepCopyInto(&out.Propagation)
+	out.Remediation = in.Remediation
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivilegeValidationRule.
@@ -124,10 +361,161 @@ func (in *Propagation) DeepCopy() *Propagation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Remediation) DeepCopyInto(out *Remediation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Remediation.
+func (in *Remediation) DeepCopy() *Remediation {
+	if in == nil {
+		return nil
+	}
+	out := new(Remediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceAllocationRequirement) DeepCopyInto(out *ResourceAllocationRequirement) {
+	*out = *in
+	if in.ExpandableReservation != nil {
+		in, out := &in.ExpandableReservation, &out.ExpandableReservation
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceAllocationRequirement.
+func (in *ResourceAllocationRequirement) DeepCopy() *ResourceAllocationRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceAllocationRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePoolValidationRule) DeepCopyInto(out *ResourcePoolValidationRule) {
+	*out = *in
+	out.ManuallyNamed = in.ManuallyNamed
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(ResourceAllocationRequirement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(ResourceAllocationRequirement)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePoolValidationRule.
+func (in *ResourcePoolValidationRule) DeepCopy() *ResourcePoolValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePoolValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleHistory) DeepCopyInto(out *RuleHistory) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.FailingSince != nil {
+		in, out := &in.FailingSince, &out.FailingSince
+		*out = (*in).DeepCopy()
+	}
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Transitions != nil {
+		in, out := &in.Transitions, &out.Transitions
+		*out = make([]RuleTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleHistory.
+func (in *RuleHistory) DeepCopy() *RuleHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleTransition) DeepCopyInto(out *RuleTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleTransition.
+func (in *RuleTransition) DeepCopy() *RuleTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupervisorValidationRule) DeepCopyInto(out *SupervisorValidationRule) {
+	*out = *in
+	out.ManuallyNamed = in.ManuallyNamed
+	if in.StoragePolicies != nil {
+		in, out := &in.StoragePolicies, &out.StoragePolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VMClasses != nil {
+		in, out := &in.VMClasses, &out.VMClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodepoolResourceRequirements != nil {
+		in, out := &in.NodepoolResourceRequirements, &out.NodepoolResourceRequirements
+		*out = make([]NodepoolResourceRequirement, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupervisorValidationRule.
+func (in *SupervisorValidationRule) DeepCopy() *SupervisorValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SupervisorValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TagValidationRule) DeepCopyInto(out *TagValidationRule) {
 	*out = *in
 	out.ManuallyNamed = in.ManuallyNamed
+	if in.AllowedTagNames != nil {
+		in, out := &in.AllowedTagNames, &out.AllowedTagNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Remediation = in.Remediation
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagValidationRule.
@@ -140,6 +528,63 @@ func (in *TagValidationRule) DeepCopy() *TagValidationRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyValidationRule) DeepCopyInto(out *TopologyValidationRule) {
+	*out = *in
+	out.ManuallyNamed = in.ManuallyNamed
+	if in.Datacenters != nil {
+		in, out := &in.Datacenters, &out.Datacenters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyValidationRule.
+func (in *TopologyValidationRule) DeepCopy() *TopologyValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMKernelAdapterRequirement) DeepCopyInto(out *VMKernelAdapterRequirement) {
+	*out = *in
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMKernelAdapterRequirement.
+func (in *VMKernelAdapterRequirement) DeepCopy() *VMKernelAdapterRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(VMKernelAdapterRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSANValidationRule) DeepCopyInto(out *VSANValidationRule) {
+	*out = *in
+	out.ManuallyNamed = in.ManuallyNamed
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSANValidationRule.
+func (in *VSANValidationRule) DeepCopy() *VSANValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(VSANValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VsphereAuth) DeepCopyInto(out *VsphereAuth) {
 	*out = *in
@@ -166,7 +611,7 @@ func (in *VsphereValidator) DeepCopyInto(out *VsphereValidator) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VsphereValidator.
@@ -233,7 +678,9 @@ func (in *VsphereValidatorSpec) DeepCopyInto(out *VsphereValidatorSpec) {
 	if in.TagValidationRules != nil {
 		in, out := &in.TagValidationRules, &out.TagValidationRules
 		*out = make([]TagValidationRule, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ComputeResourceRules != nil {
 		in, out := &in.ComputeResourceRules, &out.ComputeResourceRules
@@ -249,6 +696,86 @@ func (in *VsphereValidatorSpec) DeepCopyInto(out *VsphereValidatorSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TopologyValidationRules != nil {
+		in, out := &in.TopologyValidationRules, &out.TopologyValidationRules
+		*out = make([]TopologyValidationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HostDNSValidationRules != nil {
+		in, out := &in.HostDNSValidationRules, &out.HostDNSValidationRules
+		*out = make([]HostDNSValidationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HostNetworkRules != nil {
+		in, out := &in.HostNetworkRules, &out.HostNetworkRules
+		*out = make([]HostNetworkRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IPPoolValidationRules != nil {
+		in, out := &in.IPPoolValidationRules, &out.IPPoolValidationRules
+		*out = make([]IPPoolValidationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FolderValidationRules != nil {
+		in, out := &in.FolderValidationRules, &out.FolderValidationRules
+		*out = make([]FolderValidationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourcePoolValidationRules != nil {
+		in, out := &in.ResourcePoolValidationRules, &out.ResourcePoolValidationRules
+		*out = make([]ResourcePoolValidationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DRSRuleValidationRules != nil {
+		in, out := &in.DRSRuleValidationRules, &out.DRSRuleValidationRules
+		*out = make([]DRSRuleValidationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VSANValidationRules != nil {
+		in, out := &in.VSANValidationRules, &out.VSANValidationRules
+		*out = make([]VSANValidationRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificateValidationRules != nil {
+		in, out := &in.CertificateValidationRules, &out.CertificateValidationRules
+		*out = make([]CertificateValidationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LicenseValidationRules != nil {
+		in, out := &in.LicenseValidationRules, &out.LicenseValidationRules
+		*out = make([]LicenseValidationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SupervisorValidationRules != nil {
+		in, out := &in.SupervisorValidationRules, &out.SupervisorValidationRules
+		*out = make([]SupervisorValidationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NSXSegmentValidationRules != nil {
+		in, out := &in.NSXSegmentValidationRules, &out.NSXSegmentValidationRules
+		*out = make([]NSXSegmentValidationRule, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VsphereValidatorSpec.
@@ -264,6 +791,13 @@ func (in *VsphereValidatorSpec) DeepCopy() *VsphereValidatorSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VsphereValidatorStatus) DeepCopyInto(out *VsphereValidatorStatus) {
 	*out = *in
+	if in.RuleHistory != nil {
+		in, out := &in.RuleHistory, &out.RuleHistory
+		*out = make([]RuleHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VsphereValidatorStatus.
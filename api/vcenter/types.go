@@ -2,6 +2,7 @@
 package vcenter
 
 import (
+	"fmt"
 	"net/url"
 	"time"
 
@@ -14,6 +15,12 @@ const (
 
 	// DefaultDomain is the default vCenter domain.
 	DefaultDomain = "VSPHERE.LOCAL"
+
+	// VSANDefaultStoragePolicyName is the name of the default vSAN storage policy.
+	VSANDefaultStoragePolicyName = "vSAN Default Storage Policy"
+
+	// LicenseEditionEvaluation is the edition key of the evaluation mode license.
+	LicenseEditionEvaluation = "eval"
 )
 
 const (
@@ -60,6 +67,10 @@ const (
 	// Replacements: datacenter name, cluster name, resource pool name.
 	ResourcePoolChildInventoryGlob = "/%s/host/%s/Resources/%s/*"
 
+	// FolderInventoryPath is the path for folder inventory.
+	// Replacements: datacenter name, folder type (vm, host, datastore or network), folder name.
+	FolderInventoryPath = "/%s/%s/%s"
+
 	// VMFolderInventoryPath is the path for VM folder inventory.
 	// Replacements: datacenter name, vm folder name.
 	VMFolderInventoryPath = "/%s/vm/%s"
@@ -69,19 +80,70 @@ const (
 	VMFolderInventoryPrefix = "/%s/vm/"
 )
 
+// AuthMethod is a vCenter authentication method.
+type AuthMethod string
+
+const (
+	// AuthMethodPassword authenticates using a username and password.
+	AuthMethodPassword AuthMethod = "password"
+
+	// AuthMethodToken authenticates using a SAML token issued by the vCenter STS.
+	AuthMethodToken AuthMethod = "token"
+
+	// AuthMethodCertificate authenticates using a holder-of-key SAML token issued by the vCenter STS for a solution user certificate.
+	AuthMethodCertificate AuthMethod = "certificate"
+
+	// AuthMethodTokenExchange authenticates using a SAML token obtained by exchanging an identity provider token via vCenter identity federation.
+	AuthMethodTokenExchange AuthMethod = "tokenExchange"
+)
+
+// DRSRuleType is the type of a DRS rule.
+type DRSRuleType string
+
+const (
+	// DRSRuleTypeVMAffinity is a VM-VM affinity rule, which keeps VMs together on the same host.
+	DRSRuleTypeVMAffinity DRSRuleType = "vmAffinity"
+
+	// DRSRuleTypeVMAntiAffinity is a VM-VM anti-affinity rule, which separates VMs across hosts.
+	DRSRuleTypeVMAntiAffinity DRSRuleType = "vmAntiAffinity"
+
+	// DRSRuleTypeVMHost is a VM-Host group rule, which places a VM group on or off a host group.
+	DRSRuleTypeVMHost DRSRuleType = "vmHost"
+)
+
 // Account contains vCenter account details.
 type Account struct {
 	// Insecure controls whether to validate the vCenter server's certificate.
 	Insecure bool `json:"insecure" yaml:"insecure"`
 
 	// Password is the vCenter password.
-	Password string `json:"password" yaml:"password"`
+	Password string `json:"password,omitempty" yaml:"password,omitempty"`
 
 	// Username is the vCenter username.
-	Username string `json:"username" yaml:"username"`
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
 
 	// Host is the vCenter URL.
 	Host string `json:"host" yaml:"host"`
+
+	// Token is a SAML token issued by the vCenter Security Token Service (STS).
+	// If specified, it is used to login instead of Username and Password.
+	// If Certificate and PrivateKey are also specified, the token is treated as a holder-of-key token.
+	Token string `json:"token,omitempty" yaml:"token,omitempty"`
+
+	// Certificate is a PEM-encoded solution user certificate.
+	// If specified without Token, a holder-of-key SAML token is issued by the vCenter STS and used to login.
+	Certificate string `json:"certificate,omitempty" yaml:"certificate,omitempty"`
+
+	// PrivateKey is the PEM-encoded private key for Certificate.
+	PrivateKey string `json:"privateKey,omitempty" yaml:"privateKey,omitempty"`
+
+	// FederatedToken is an OAuth 2.0 token issued by an external identity provider, e.g., a Kubernetes service account token.
+	// If specified, it is exchanged for a SAML token via the vCenter 8 identity federation token exchange service and used to login.
+	FederatedToken string `json:"federatedToken,omitempty" yaml:"federatedToken,omitempty"`
+
+	// FederatedTokenType is the type of FederatedToken. Defaults to access_token.
+	// +kubebuilder:validation:Enum=access_token;id_token
+	FederatedTokenType string `json:"federatedTokenType,omitempty" yaml:"federatedTokenType,omitempty"`
 }
 
 // Userinfo returns a vCenter account's credentials in Userinfo format.
@@ -89,6 +151,20 @@ func (a Account) Userinfo() *url.Userinfo {
 	return url.UserPassword(a.Username, a.Password)
 }
 
+// AuthMethod returns the method used to authenticate a vCenter account.
+func (a Account) AuthMethod() AuthMethod {
+	switch {
+	case a.FederatedToken != "":
+		return AuthMethodTokenExchange
+	case a.Token != "":
+		return AuthMethodToken
+	case a.Certificate != "":
+		return AuthMethodCertificate
+	default:
+		return AuthMethodPassword
+	}
+}
+
 // Datastore defines a datastore
 type Datastore struct {
 	Name string
@@ -108,15 +184,172 @@ type HostDateInfo struct {
 	NTPServers    []string
 	Service       *types.HostService
 	Current       *time.Time
+	Skew          time.Duration
 	ClientStatus  string
 	ServiceStatus string
 }
 
 // Servers returns a slice of NTP servers for a vCenter host system.
 func (i *HostDateInfo) Servers() []string {
+	if i.NtpConfig == nil {
+		return nil
+	}
 	return i.NtpConfig.Server
 }
 
+// TopologyDatacenter defines the region tags attached to a vCenter datacenter and its zone entities.
+type TopologyDatacenter struct {
+	Name     string
+	Regions  []string
+	Entities []TopologyEntity
+}
+
+// TopologyEntity defines the zone tags attached to a vCenter cluster or host system,
+// along with the shared datastores and networks available to it.
+type TopologyEntity struct {
+	Name             string
+	Zones            []string
+	SharedDatastores []string
+	Networks         []string
+}
+
+// FolderSummary defines a vCenter folder and its direct children.
+type FolderSummary struct {
+	InventoryPath string
+	ChildTypes    []string
+	VMs           []string
+}
+
+// ResourcePoolConfig defines the CPU and memory allocation of a vCenter resource pool.
+type ResourcePoolConfig struct {
+	InventoryPath string
+	CPU           ResourceAllocation
+	Memory        ResourceAllocation
+}
+
+// ResourceAllocation defines the allocation of a resource (CPU in MHz, memory in MB) to a vCenter resource pool.
+type ResourceAllocation struct {
+	Reservation           int64
+	Limit                 int64 // -1 if unlimited
+	SharesLevel           string
+	Shares                int32
+	ExpandableReservation bool
+}
+
+// ClusterDRSConfig defines the DRS configuration and rules of a vCenter cluster.
+type ClusterDRSConfig struct {
+	DRSEnabled bool
+	Rules      []DRSRule
+	VMs        []string
+}
+
+// DRSRule defines a DRS rule. For VM-Host rules, VMs are the members of the rule's VM group.
+type DRSRule struct {
+	Name      string
+	Type      DRSRuleType
+	Enabled   bool
+	Mandatory bool
+	VMs       []string
+	VMGroup   string
+	HostGroup string
+}
+
+// VSANClusterInfo defines the vSAN configuration, health, capacity and disk usage of a vCenter cluster.
+type VSANClusterInfo struct {
+	Enabled                  bool
+	OverallHealth            string
+	OverallHealthDescription string
+	HealthTests              []VSANHealthTest
+	TotalCapacityBytes       int64
+	FreeCapacityBytes        int64
+	HostDisksInUse           map[string]int
+}
+
+// VSANHealthTest defines the result of a vSAN health test.
+type VSANHealthTest struct {
+	Group  string
+	Name   string
+	Health string
+}
+
+// VSANStoragePolicy defines the data protection settings of a vSAN storage policy.
+type VSANStoragePolicy struct {
+	Name               string
+	FailuresToTolerate int32
+	ErasureCoding      bool
+}
+
+// Certificate defines an X.509 certificate presented by vCenter or an ESXi host.
+type Certificate struct {
+	Subject   string
+	Issuer    string
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// HostCertificate defines the certificate of an ESXi host.
+type HostCertificate struct {
+	Host        string
+	Certificate Certificate
+}
+
+// License defines a vCenter license and its usage.
+type License struct {
+	Name       string
+	EditionKey string
+	CostUnit   string
+	Total      int32
+	Used       int32
+	Features   []string
+	// ExpirationDate is nil for licenses that do not expire.
+	ExpirationDate *time.Time
+}
+
+// Evaluation returns whether the license is an evaluation mode license.
+func (l License) Evaluation() bool {
+	return l.EditionKey == LicenseEditionEvaluation
+}
+
+// HostLicense defines the license assigned to an ESXi host.
+type HostLicense struct {
+	Host    string
+	License License
+}
+
+// SupervisorCluster defines the Workload Management status of a vCenter cluster.
+type SupervisorCluster struct {
+	ClusterID        string
+	Enabled          bool
+	ConfigStatus     string
+	KubernetesStatus string
+}
+
+// Namespace defines a vSphere Namespace, its storage policy and VM class bindings, and its resource quotas and usage.
+// A limit of zero denotes an unlimited quota.
+type Namespace struct {
+	Name            string
+	ClusterID       string
+	ConfigStatus    string
+	StoragePolicies []string
+	VMClasses       []string
+	CPULimitMHz     int64
+	CPUUsedMHz      int64
+	MemoryLimitMiB  int64
+	MemoryUsedMiB   int64
+	StorageLimitMiB int64
+	StorageUsedMiB  int64
+}
+
+// NSXSegment defines an NSX segment, backed by either an opaque network or an NSX distributed port group,
+// and the hosts it is attached to.
+type NSXSegment struct {
+	Name            string
+	Type            string
+	NSX             bool
+	LogicalSwitchID string
+	Hosts           []string
+}
+
 // Network defines a vCenter network.
 type Network struct {
 	Type      string
@@ -177,3 +410,69 @@ type VM struct {
 	Metrics        Metrics
 	Storage        []Datastore
 }
+
+// HostDNSConfig defines the DNS configuration of a vCenter host system.
+type HostDNSConfig struct {
+	Name          string
+	HostName      string
+	DomainName    string
+	DNSServers    []string
+	SearchDomains []string
+	IPAddresses   []string
+}
+
+// FQDN returns the fully qualified domain name of a vCenter host system.
+func (c HostDNSConfig) FQDN() string {
+	if c.DomainName == "" {
+		return c.HostName
+	}
+	return c.HostName + "." + c.DomainName
+}
+
+// HostNetworkConfig defines the VMkernel adapters and physical NICs of a vCenter host system.
+type HostNetworkConfig struct {
+	Name             string
+	VMKernelAdapters []VMKernelAdapter
+	PhysicalNICs     []PhysicalNIC
+}
+
+// VMKernelAdapter defines a VMkernel adapter and the services enabled on it.
+type VMKernelAdapter struct {
+	Device    string
+	Services  []string
+	MTU       int
+	IPAddress string
+}
+
+// PhysicalNIC defines a physical NIC and its link state. SpeedMb is zero if the link is down.
+type PhysicalNIC struct {
+	Device  string
+	SpeedMb int
+}
+
+// Event defines a vCenter event, e.g., a permission change or host disconnect, that may affect the outcome of validation.
+type Event struct {
+	Type        string
+	UserName    string
+	CreatedTime time.Time
+	Entity      string
+	// Cluster is the name of the cluster or standalone compute resource of the host the event occurred on, if any.
+	Cluster string
+	Message string
+}
+
+// String returns a description of who caused an event and when.
+func (e Event) String() string {
+	s := e.Type
+	if e.Entity != "" {
+		s += fmt.Sprintf(" on %s", e.Entity)
+	}
+	if e.UserName != "" {
+		s += fmt.Sprintf(" by %s", e.UserName)
+	}
+	s += fmt.Sprintf(" at %s", e.CreatedTime.UTC().Format(time.RFC3339))
+	if e.Message != "" {
+		s += fmt.Sprintf(": %s", e.Message)
+	}
+	return s
+}
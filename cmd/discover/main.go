@@ -0,0 +1,91 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command discover snapshots the current state of a vCenter datacenter as a baseline VsphereValidatorSpec.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/discovery"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+// passwordEnv is the environment variable that holds the vCenter password, so that it is not exposed on the command line.
+const passwordEnv = "VSPHERE_PASSWORD"
+
+func main() {
+	var account vcenter.Account
+	var datacenter, clusters, output string
+	var capacityFraction float64
+	flag.StringVar(&account.Host, "host", "", "The vCenter URL.")
+	flag.StringVar(&account.Username, "username", "", "The vCenter username. The password is read from "+passwordEnv+".")
+	flag.BoolVar(&account.Insecure, "insecure", false, "If set, the vCenter server's certificate is not validated.")
+	flag.StringVar(&datacenter, "datacenter", "", "The datacenter to snapshot.")
+	flag.StringVar(&clusters, "clusters", "", "A comma-separated list of the clusters to snapshot. Defaults to every cluster in the datacenter.")
+	flag.Float64Var(&capacityFraction, "capacity-fraction", discovery.DefaultCapacityFraction,
+		"The fraction of each cluster's free CPU, memory and storage that the snapshot requires.")
+	flag.StringVar(&output, "output", "", "The file the snapshot is written to. Defaults to stdout.")
+	opts := zap.Options{
+		Development: true,
+		DestWriter:  os.Stderr,
+	}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	log := ctrl.Log.WithName("discover")
+
+	if account.Host == "" || account.Username == "" || datacenter == "" {
+		log.Info("--host, --username and --datacenter are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	account.Password = os.Getenv(passwordEnv)
+
+	driver, err := vsphere.NewVCenterDriver(account, datacenter, log)
+	if err != nil {
+		log.Error(err, "failed to create vCenter driver")
+		os.Exit(1)
+	}
+
+	snapshotOpts := discovery.Options{CapacityFraction: capacityFraction}
+	if clusters != "" {
+		snapshotOpts.Clusters = strings.Split(clusters, ",")
+	}
+	snapshot, err := discovery.SnapshotYAML(context.Background(), driver, snapshotOpts, log)
+	if err != nil {
+		log.Error(err, "failed to snapshot vCenter")
+		os.Exit(1)
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(snapshot)
+	} else {
+		err = os.WriteFile(output, snapshot, 0o600)
+	}
+	if err != nil {
+		log.Error(err, "failed to write snapshot")
+		os.Exit(1)
+	}
+}
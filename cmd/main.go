@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
@@ -31,12 +32,14 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	validationv1alpha1 "github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
 	"github.com/validator-labs/validator-plugin-vsphere/internal/controller"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
 	validatorv1alpha1 "github.com/validator-labs/validator/api/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
@@ -169,6 +172,17 @@ func main() {
 		os.Exit(1)
 	}
 
+	// log out of all vCenter sessions when the manager shuts down
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		setupLog.Info("logging out of vCenter sessions")
+		vsphere.CloseSessions(context.Background())
+		return nil
+	})); err != nil {
+		setupLog.Error(err, "unable to set up vCenter session cleanup")
+		os.Exit(1)
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
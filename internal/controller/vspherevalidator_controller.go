@@ -21,24 +21,49 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ktypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
 	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
 	"github.com/validator-labs/validator-plugin-vsphere/pkg/validate"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
 	vapi "github.com/validator-labs/validator/api/v1alpha1"
 	vres "github.com/validator-labs/validator/pkg/validationresult"
 )
 
+// SessionCleanupFinalizer ensures that vCenter sessions are logged out when the last VsphereValidator using them is deleted.
+const SessionCleanupFinalizer = "validator/vsphere-session-cleanup"
+
+const (
+	// requeueInterval is the interval between re-validations of a VsphereValidator.
+	requeueInterval = 2 * time.Minute
+
+	// watchedRequeueInterval is the interval between re-validations of a VsphereValidator whose vCenter
+	// inventory is watched. Inventory changes trigger re-validation immediately, so this is only a safety net.
+	watchedRequeueInterval = 30 * time.Minute
+
+	// triggerBufferSize is the number of re-validation triggers buffered before notifying watches block.
+	triggerBufferSize = 100
+)
+
 var errCredentialsRequired = errors.New("auth.secretName or auth.cloudAccount is required")
 
 // VsphereValidatorReconciler reconciles a VsphereValidator object
@@ -46,6 +71,11 @@ type VsphereValidatorReconciler struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
+
+	watcher        *vsphere.InventoryWatcher
+	eventCollector *vsphere.EventCollector
+	triggers       chan event.GenericEvent
+	stopped        chan struct{}
 }
 
 // +kubebuilder:rbac:groups=validation.spectrocloud.labs,resources=vspherevalidators,verbs=get;list;watch;create;update;patch;delete
@@ -69,6 +99,26 @@ func (r *VsphereValidatorReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Release the validator's vCenter session on deletion
+	if !validator.DeletionTimestamp.IsZero() {
+		l.Info("Releasing vCenter session for deleted VsphereValidator")
+		vsphere.ReleaseSession(ctx, req.NamespacedName.String())
+		r.watcher.Unwatch(req.NamespacedName.String())
+		r.eventCollector.Unwatch(req.NamespacedName.String())
+		if controllerutil.RemoveFinalizer(validator, SessionCleanupFinalizer) {
+			if err := r.Update(ctx, validator); err != nil {
+				return ctrl.Result{}, client.IgnoreNotFound(err)
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+	if controllerutil.AddFinalizer(validator, SessionCleanupFinalizer) {
+		if err := r.Update(ctx, validator); err != nil {
+			l.Error(err, "failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Get the active validator's validation result
 	vr := &vapi.ValidationResult{}
 	p, err := patch.NewHelper(vr, r.Client)
@@ -106,17 +156,57 @@ func (r *VsphereValidatorReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 	}
 
-	// Validate the rules
+	// Hold a reference to the vCenter session until the validator is deleted
+	if _, err := vsphere.AcquireSession(ctx, req.NamespacedName.String(), *validator.Spec.Auth.Account); err != nil {
+		l.Error(err, "failed to acquire vCenter session")
+	}
+
+	// Watch the vCenter inventory for changes, if enabled
+	requeueAfter := requeueInterval
+	if validator.Spec.WatchInventory {
+		if err := r.watcher.Watch(req.NamespacedName.String(), *validator.Spec.Auth.Account, validator.Spec.ValidationTypes()); err != nil {
+			l.Error(err, "failed to watch vCenter inventory")
+		}
+		// resource usage is not watched, so compute resource rules are still re-validated periodically
+		if len(validator.Spec.ComputeResourceRules) == 0 {
+			requeueAfter = watchedRequeueInterval
+		}
+	} else {
+		r.watcher.Unwatch(req.NamespacedName.String())
+	}
+
+	// Tail the vCenter event stream for events that could affect the rules, if enabled
+	var triggeringEvents []vcenter.Event
+	if validator.Spec.WatchEvents {
+		if err := r.eventCollector.Watch(req.NamespacedName.String(), *validator.Spec.Auth.Account, validator.Spec.ValidationTypes()); err != nil {
+			l.Error(err, "failed to watch vCenter events")
+		}
+		triggeringEvents = r.eventCollector.Drain(req.NamespacedName.String())
+	} else {
+		r.eventCollector.Unwatch(req.NamespacedName.String())
+	}
+
+	// Validate the rules and record their outcomes to detect drift since the previous run
 	resp := validate.Validate(ctx, validator.Spec, r.Log)
+	statusPatch := client.MergeFrom(validator.DeepCopy())
+	validator.Status.RuleHistory = validate.RecordHistory(validator.Status.RuleHistory, &resp, time.Now())
+	if validator.Spec.AttachTriggeringEvents {
+		validate.AttachEvents(&resp, validator.Spec, triggeringEvents)
+	}
 
 	// Patch the ValidationResult with the latest ValidationRuleResults
 	if err := vres.SafeUpdate(ctx, p, vr, resp, r.Log); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	// requeue after two minutes for re-validation
-	l.Info("Requeuing for re-validation in two minutes.")
-	return ctrl.Result{RequeueAfter: 2 * time.Minute}, nil
+	// Patch the VsphereValidator's status with the latest rule history
+	if err := r.Status().Patch(ctx, validator, statusPatch); err != nil {
+		l.Error(err, "failed to patch VsphereValidator rule history")
+	}
+
+	// requeue for re-validation
+	l.Info("Requeuing for re-validation.", "requeueAfter", requeueAfter)
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *VsphereValidatorReconciler) secretKeyAuth(req ctrl.Request, validator *v1alpha1.VsphereValidator) error {
@@ -128,14 +218,29 @@ func (r *VsphereValidatorReconciler) secretKeyAuth(req ctrl.Request, validator *
 		return fmt.Errorf("failed to get secret %s: %w", validator.Spec.Auth.SecretName, err)
 	}
 
-	username, ok := authSecret.Data["username"]
-	if !ok {
-		return errors.New("auth secret missing username")
+	account := &vcenter.Account{
+		Username:           string(authSecret.Data["username"]),
+		Password:           string(authSecret.Data["password"]),
+		Token:              string(authSecret.Data["token"]),
+		Certificate:        string(authSecret.Data["certificate"]),
+		PrivateKey:         string(authSecret.Data["privateKey"]),
+		FederatedToken:     string(authSecret.Data["federatedToken"]),
+		FederatedTokenType: string(authSecret.Data["federatedTokenType"]),
 	}
-	password, ok := authSecret.Data["password"]
-	if !ok {
-		return errors.New("auth secret missing password")
+	switch account.AuthMethod() {
+	case vcenter.AuthMethodPassword:
+		if _, ok := authSecret.Data["username"]; !ok {
+			return errors.New("auth secret missing username")
+		}
+		if _, ok := authSecret.Data["password"]; !ok {
+			return errors.New("auth secret missing password")
+		}
+	case vcenter.AuthMethodCertificate:
+		if account.PrivateKey == "" {
+			return errors.New("auth secret missing privateKey")
+		}
 	}
+
 	vcenterServer, ok := authSecret.Data["vcenterServer"]
 	if !ok {
 		return errors.New("auth secret missing vcenterServer")
@@ -149,19 +254,58 @@ func (r *VsphereValidatorReconciler) secretKeyAuth(req ctrl.Request, validator *
 		return fmt.Errorf("failed to convert insecureSkipVerify to bool: %w", err)
 	}
 
-	validator.Spec.Auth.Account = &vcenter.Account{
-		Insecure: skipVerify,
-		Username: string(username),
-		Password: string(password),
-		Host:     string(vcenterServer),
-	}
+	account.Insecure = skipVerify
+	account.Host = string(vcenterServer)
+	validator.Spec.Auth.Account = account
 
 	return nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *VsphereValidatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.triggers = make(chan event.GenericEvent, triggerBufferSize)
+	r.stopped = make(chan struct{})
+	r.watcher = vsphere.NewInventoryWatcher(r.enqueue, r.Log.WithName("InventoryWatcher"))
+	r.eventCollector = vsphere.NewEventCollector(r.enqueue, r.Log.WithName("EventCollector"))
+
+	// stop all inventory watches and event collectors when the manager shuts down
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		close(r.stopped)
+		r.watcher.Close()
+		r.eventCollector.Close()
+		return nil
+	})); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.VsphereValidator{}).
+		For(&v1alpha1.VsphereValidator{}, builder.WithPredicates(predicate.Or(
+			// status updates, e.g., of rule history, don't trigger re-validation
+			predicate.GenerationChangedPredicate{},
+			predicate.Funcs{UpdateFunc: func(e event.UpdateEvent) bool {
+				return !e.ObjectNew.GetDeletionTimestamp().IsZero()
+			}},
+		))).
+		WatchesRawSource(source.Channel(r.triggers, &handler.EnqueueRequestForObject{})).
 		Complete(r)
 }
+
+// enqueue triggers re-validation of the VsphereValidators affected by a change to their vCenter. It blocks while the
+// trigger buffer is full, unless the manager is shutting down.
+func (r *VsphereValidatorReconciler) enqueue(owners []string) {
+	for _, owner := range owners {
+		namespace, name, ok := strings.Cut(owner, string(ktypes.Separator))
+		if !ok {
+			continue
+		}
+		e := event.GenericEvent{
+			Object: &v1alpha1.VsphereValidator{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+		}
+		select {
+		case r.triggers <- e:
+		case <-r.stopped:
+			return
+		}
+	}
+}
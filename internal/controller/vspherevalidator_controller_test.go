@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	v1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -94,6 +95,21 @@ var _ = Describe("VsphereValidator controller", Ordered, func() {
 			stateOk := vr.Status.State == vapi.ValidationFailed
 			return stateOk
 		}, timeout, interval).Should(BeTrue(), "failed to create a ValidationResult")
+	})
+
+	It("Should remove the session cleanup finalizer when the VsphereValidator is deleted", func() {
+		By("By deleting the VsphereValidator")
+		ctx := context.Background()
+		key := types.NamespacedName{Name: vsphereValidatorName, Namespace: validatorNamespace}
+
+		Expect(k8sClient.Get(ctx, key, val)).Should(Succeed())
+		Expect(val.Finalizers).To(ContainElement(SessionCleanupFinalizer))
+		Expect(k8sClient.Delete(ctx, val)).Should(Succeed())
+
+		// Wait for the finalizer to be removed and the VsphereValidator to be deleted
+		Eventually(func() bool {
+			return apierrs.IsNotFound(k8sClient.Get(ctx, key, &v1alpha1.VsphereValidator{}))
+		}, timeout, interval).Should(BeTrue(), "failed to delete the VsphereValidator")
 
 		vcSim.Shutdown()
 	})
@@ -16,4 +16,40 @@ const (
 
 	// ValidationTypeNTP is the validation type for NTP
 	ValidationTypeNTP string = "vsphere-ntp"
+
+	// ValidationTypeTopology is the validation type for Kubernetes region/zone topology
+	ValidationTypeTopology string = "vsphere-topology"
+
+	// ValidationTypeHostDNS is the validation type for ESXi host DNS configuration
+	ValidationTypeHostDNS string = "vsphere-host-dns"
+
+	// ValidationTypeHostNetwork is the validation type for ESXi host VMkernel adapters and physical NICs
+	ValidationTypeHostNetwork string = "vsphere-host-network"
+
+	// ValidationTypeIPPool is the validation type for static IP pools
+	ValidationTypeIPPool string = "vsphere-ip-pool"
+
+	// ValidationTypeFolder is the validation type for folders
+	ValidationTypeFolder string = "vsphere-folder"
+
+	// ValidationTypeResourcePool is the validation type for resource pool configuration
+	ValidationTypeResourcePool string = "vsphere-resource-pool"
+
+	// ValidationTypeDRSRule is the validation type for DRS rules
+	ValidationTypeDRSRule string = "vsphere-drs-rule"
+
+	// ValidationTypeVSAN is the validation type for vSAN clusters
+	ValidationTypeVSAN string = "vsphere-vsan"
+
+	// ValidationTypeCertificate is the validation type for vCenter and ESXi host certificates
+	ValidationTypeCertificate string = "vsphere-certificate"
+
+	// ValidationTypeLicense is the validation type for ESXi host licenses
+	ValidationTypeLicense string = "vsphere-license"
+
+	// ValidationTypeSupervisor is the validation type for vSphere with Tanzu Supervisors and vSphere Namespaces
+	ValidationTypeSupervisor string = "vsphere-supervisor"
+
+	// ValidationTypeNSXSegment is the validation type for NSX segments
+	ValidationTypeNSXSegment string = "vsphere-nsx-segment"
 )
@@ -0,0 +1,366 @@
+// Package discovery snapshots the current state of a vCenter as a baseline VsphereValidatorSpec.
+package discovery
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter/entity"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/computeresources"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+// DefaultCapacityFraction is the default fraction of each cluster's free capacity that a snapshot requires.
+const DefaultCapacityFraction = 0.9
+
+// Entity is a vCenter entity that the account's privileges are captured on.
+type Entity struct {
+	EntityType  string
+	EntityName  string
+	ClusterName string
+}
+
+// Options configures which parts of a vCenter are captured in a snapshot.
+type Options struct {
+	// Entities are the entities that the account's privileges are captured on, in addition to the datacenter's
+	// datastores and networks, which are always captured. If empty, privileges are also captured on the datacenter
+	// and each of its clusters.
+	Entities []Entity
+
+	// Clusters are the clusters whose tags, NTP servers and capacity are captured. If empty, every cluster in the
+	// datacenter is captured.
+	Clusters []string
+
+	// CapacityFraction is the fraction of each cluster's free CPU, memory and storage that the snapshot's compute
+	// resource rules require, leaving headroom for normal fluctuation. Defaults to DefaultCapacityFraction.
+	CapacityFraction float64
+}
+
+// Snapshot connects to vCenter with the driver's account and returns a VsphereValidatorSpec that captures the current
+// state of the driver's datacenter: the account's privileges on the chosen entities, the tags attached to the
+// datacenter and to each cluster and its hosts, each cluster's NTP servers and free capacity, and the datastores
+// and networks that exist. Validating the spec later detects drift from the snapshot. Auth is left unset.
+func Snapshot(ctx context.Context, driver *vsphere.VCenterDriver, opts Options, log logr.Logger) (*v1alpha1.VsphereValidatorSpec, error) {
+	datacenter := driver.Datacenter
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, datacenter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get finder with datacenter: %w", err)
+	}
+
+	clusters := opts.Clusters
+	if len(clusters) == 0 {
+		clusters, err = driver.GetClusters(ctx, datacenter)
+		if err != nil {
+			return nil, err
+		}
+	}
+	entities := opts.Entities
+	if len(entities) == 0 {
+		entities = defaultEntities(datacenter, clusters)
+	}
+	if err := driver.LoadInventory(ctx, targetsVMs(entities)); err != nil {
+		log.Error(err, "failed to load vCenter inventory; falling back to per-rule lookups")
+	}
+	inventory, err := inventoryEntities(ctx, driver, datacenter)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range inventory {
+		if !slices.ContainsFunc(entities, func(o Entity) bool { return o.EntityType == e.EntityType && o.EntityName == e.EntityName }) {
+			entities = append(entities, e)
+		}
+	}
+	fraction := opts.CapacityFraction
+	if fraction <= 0 {
+		fraction = DefaultCapacityFraction
+	}
+
+	spec := &v1alpha1.VsphereValidatorSpec{Datacenter: datacenter}
+
+	spec.PrivilegeValidationRules, err = privilegeRules(ctx, driver, finder, entities)
+	if err != nil {
+		return nil, err
+	}
+	spec.TagValidationRules, err = tagRules(ctx, driver, finder, clusters)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range clusters {
+		ntpRule, err := ntpRule(ctx, driver, finder, cluster)
+		switch {
+		case err != nil:
+			log.Error(err, "Skipping NTP snapshot; failed to get the cluster's NTP servers", "cluster", cluster)
+		case ntpRule == nil:
+			log.Info("Skipping NTP snapshot; the cluster's hosts have inconsistent or no NTP servers", "cluster", cluster)
+		default:
+			spec.NTPValidationRules = append(spec.NTPValidationRules, *ntpRule)
+		}
+
+		rule := v1alpha1.ComputeResourceRule{
+			RuleName:   fmt.Sprintf("%s %s capacity", entity.Cluster, cluster),
+			Scope:      entity.Cluster.String(),
+			EntityName: cluster,
+		}
+		usage, err := computeresources.GetUsage(ctx, rule, finder, driver)
+		if err != nil {
+			return nil, err
+		}
+		requirement, ok := capacityRequirement(usage, fraction)
+		if !ok {
+			log.Info("Skipping capacity snapshot; the cluster has no free CPU, memory or shared storage", "cluster", cluster)
+			continue
+		}
+		rule.NodepoolResourceRequirements = []v1alpha1.NodepoolResourceRequirement{requirement}
+		spec.ComputeResourceRules = append(spec.ComputeResourceRules, rule)
+	}
+
+	return spec, nil
+}
+
+// SnapshotYAML returns a Snapshot of a vCenter as VsphereValidatorSpec YAML.
+func SnapshotYAML(ctx context.Context, driver *vsphere.VCenterDriver, opts Options, log logr.Logger) ([]byte, error) {
+	spec, err := Snapshot(ctx, driver, opts, log)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(spec); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// defaultEntities returns the datacenter and its clusters
+func defaultEntities(datacenter string, clusters []string) []Entity {
+	entities := []Entity{{EntityType: entity.Datacenter.String(), EntityName: datacenter}}
+	for _, c := range clusters {
+		entities = append(entities, Entity{EntityType: entity.Cluster.String(), EntityName: c})
+	}
+	return entities
+}
+
+// targetsVMs reports whether any of the entities is a VM or vApp, so that the inventory snapshot must include them
+func targetsVMs(entities []Entity) bool {
+	for _, e := range entities {
+		switch entity.Map[e.EntityType] {
+		case entity.VirtualMachine, entity.VirtualApp:
+			return true
+		}
+	}
+	return false
+}
+
+// inventoryEntities returns the datacenter's datastores and networks
+func inventoryEntities(ctx context.Context, driver *vsphere.VCenterDriver, datacenter string) ([]Entity, error) {
+	entities := make([]Entity, 0)
+
+	datastores, err := driver.GetDatastores(ctx, datacenter)
+	if err != nil {
+		return nil, err
+	}
+	for _, ds := range datastores {
+		entities = append(entities, Entity{EntityType: entity.Datastore.String(), EntityName: ds})
+	}
+
+	networks, err := driver.GetNetworks(ctx, datacenter)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range networks {
+		entities = append(entities, Entity{EntityType: entity.Network.String(), EntityName: n})
+	}
+
+	portgroups, err := driver.GetDistributedVirtualPortgroups(ctx, datacenter)
+	if err != nil {
+		return nil, err
+	}
+	for _, pg := range portgroups {
+		entities = append(entities, Entity{EntityType: entity.DistributedVirtualPortgroup.String(), EntityName: pg})
+	}
+
+	return entities, nil
+}
+
+// privilegeRules returns a privilege validation rule per entity that requires the account's current privileges on it
+func privilegeRules(ctx context.Context, driver *vsphere.VCenterDriver, finder *find.Finder, entities []Entity) ([]v1alpha1.PrivilegeValidationRule, error) {
+	username, err := driver.CurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	rules := make([]v1alpha1.PrivilegeValidationRule, 0, len(entities))
+	refs := make([]types.ManagedObjectReference, 0, len(entities))
+	for _, e := range entities {
+		rule := v1alpha1.PrivilegeValidationRule{
+			RuleName:    fmt.Sprintf("%s %s privileges", e.EntityType, e.EntityName),
+			ClusterName: e.ClusterName,
+			EntityType:  e.EntityType,
+			EntityName:  e.EntityName,
+		}
+		ref, err := driver.GetPrivilegeRuleEntity(ctx, driver.Datacenter, finder, rule)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find %s %s: %w", e.EntityType, e.EntityName, err)
+		}
+		rules = append(rules, rule)
+		refs = append(refs, ref)
+	}
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	authManager := object.NewAuthorizationManager(driver.Client.Client)
+	results, err := authManager.FetchUserPrivilegeOnEntities(ctx, refs, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch privileges for user %s: %w", username, err)
+	}
+
+	privileges := make(map[types.ManagedObjectReference][]string, len(results))
+	for _, r := range results {
+		privileges[r.Entity] = append(privileges[r.Entity], r.Privileges...)
+	}
+	for i, ref := range refs {
+		privs := slices.Clone(privileges[ref])
+		slices.Sort(privs)
+		rules[i].Privileges = slices.Compact(privs)
+	}
+	return rules, nil
+}
+
+// tagRules returns a tag validation rule per tag attached to the datacenter and to each cluster and its hosts
+func tagRules(ctx context.Context, driver *vsphere.VCenterDriver, finder *find.Finder, clusters []string) ([]v1alpha1.TagValidationRule, error) {
+	datacenter := driver.Datacenter
+
+	dc, err := driver.GetDatacenter(ctx, finder, datacenter)
+	if err != nil {
+		return nil, err
+	}
+	entities := []v1alpha1.TagValidationRule{{EntityType: entity.Datacenter.String(), EntityName: "/" + datacenter}}
+	refs := []mo.Reference{dc.Reference()}
+
+	for _, c := range clusters {
+		cluster, err := driver.GetCluster(ctx, finder, datacenter, c)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, v1alpha1.TagValidationRule{EntityType: entity.Cluster.String(), EntityName: c})
+		refs = append(refs, cluster.Reference())
+
+		hosts, err := driver.GetHostSystems(ctx, datacenter, c)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hosts {
+			host, err := driver.GetHost(ctx, finder, datacenter, c, h.Name)
+			if err != nil {
+				return nil, err
+			}
+			entities = append(entities, v1alpha1.TagValidationRule{EntityType: entity.Host.String(), ClusterName: c, EntityName: h.Name})
+			refs = append(refs, host.Reference())
+		}
+	}
+
+	tagsManager := tags.NewManager(driver.RestClient)
+	categories, err := tagsManager.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag categories: %w", err)
+	}
+	categoryNames := make(map[string]string, len(categories))
+	for _, c := range categories {
+		categoryNames[c.ID] = c.Name
+	}
+
+	attachedTags, err := tagsManager.GetAttachedTagsOnObjects(ctx, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attached tags: %w", err)
+	}
+	attached := make(map[string][]tags.Tag, len(attachedTags))
+	for _, a := range attachedTags {
+		attached[a.ObjectID.Reference().Value] = a.Tags
+	}
+
+	rules := make([]v1alpha1.TagValidationRule, 0)
+	for i, ref := range refs {
+		entityTags := attached[ref.Reference().Value]
+		slices.SortFunc(entityTags, func(a, b tags.Tag) int {
+			if c := cmp.Compare(categoryNames[a.CategoryID], categoryNames[b.CategoryID]); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Name, b.Name)
+		})
+		for _, t := range entityTags {
+			rule := entities[i]
+			rule.Tag = categoryNames[t.CategoryID]
+			rule.TagName = t.Name
+			rule.RuleName = fmt.Sprintf("%s %s tag %s %s", rule.EntityType, rule.EntityName, rule.Tag, rule.TagName)
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// ntpRule returns an NTP validation rule that requires a cluster's hosts to use their current NTP servers,
+// or nil if the hosts don't share the same, non-empty set of NTP servers
+func ntpRule(ctx context.Context, driver *vsphere.VCenterDriver, finder *find.Finder, cluster string) (*v1alpha1.NTPValidationRule, error) {
+	hostServers, err := driver.GetHostNTPServers(ctx, finder, driver.Datacenter, cluster)
+	if err != nil {
+		return nil, err
+	}
+	servers, ok := commonServers(hostServers)
+	if !ok {
+		return nil, nil
+	}
+	return &v1alpha1.NTPValidationRule{
+		RuleName:        fmt.Sprintf("%s %s NTP servers", entity.Cluster, cluster),
+		ClusterName:     cluster,
+		ExpectedServers: servers,
+		ServerMatch:     vsphere.NTPServerMatchExact,
+	}, nil
+}
+
+// commonServers returns the sorted NTP servers shared by every host, if every host has the same, non-empty set
+func commonServers(hostServers map[string][]string) ([]string, bool) {
+	var common []string
+	for _, servers := range hostServers {
+		sorted := slices.Clone(servers)
+		slices.Sort(sorted)
+		if len(sorted) == 0 || (common != nil && !slices.Equal(common, sorted)) {
+			return nil, false
+		}
+		common = sorted
+	}
+	return common, common != nil
+}
+
+// capacityRequirement returns a single node requirement for a fraction of the free CPU, memory and storage in usage,
+// or false if any of them would be zero
+func capacityRequirement(usage *computeresources.Usage, fraction float64) (v1alpha1.NodepoolResourceRequirement, bool) {
+	cpuMHz := int64(float64(usage.CPU.Free) * fraction)
+	memoryMi := int64(float64(usage.Memory.Free)*fraction) >> 20
+	diskMi := int64(float64(usage.Storage.Free)*fraction) >> 20
+	if cpuMHz <= 0 || memoryMi <= 0 || diskMi <= 0 {
+		return v1alpha1.NodepoolResourceRequirement{}, false
+	}
+	return v1alpha1.NodepoolResourceRequirement{
+		Name:          "snapshot",
+		NumberOfNodes: 1,
+		CPU:           fmt.Sprintf("%dMHz", cpuMHz),
+		Memory:        fmt.Sprintf("%dMi", memoryMi),
+		DiskSpace:     fmt.Sprintf("%dMi", diskMi),
+	}, true
+}
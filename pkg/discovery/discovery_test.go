@@ -0,0 +1,178 @@
+package discovery
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/vapi/tags"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validate"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/computeresources"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+func TestSnapshot(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin2@vsphere.local", 8478, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	ctx := context.Background()
+	driver, err := vsphere.NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cluster, err := driver.GetCluster(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tagsManager := tags.NewManager(driver.RestClient)
+	categoryID, err := tagsManager.CreateCategory(ctx, &tags.Category{Name: "k8s-zone", Cardinality: "SINGLE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tagID, err := tagsManager.CreateTag(ctx, &tags.Tag{Name: "zone-a", CategoryID: categoryID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tagsManager.AttachTag(ctx, tagID, cluster.Reference()); err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := Snapshot(ctx, driver, Options{Clusters: []string{vcSim.Options.Cluster}}, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, vcSim.Options.Datacenter, spec.Datacenter)
+
+	entities := make([]string, 0, len(spec.PrivilegeValidationRules))
+	for _, r := range spec.PrivilegeValidationRules {
+		entities = append(entities, r.EntityType+"/"+r.EntityName)
+		assert.Contains(t, r.Privileges, "System.Read")
+	}
+	assert.Contains(t, entities, "Datacenter/DC0")
+	assert.Contains(t, entities, "Cluster/DC0_C0")
+	assert.NotContains(t, entities, "Cluster/DC0_C1")
+	assert.Contains(t, entities, "Datastore/LocalDS_0")
+	assert.Contains(t, entities, "Network/VM Network")
+	assert.Contains(t, entities, "Distributed Port Group/DC0_DVPG0")
+
+	assert.Equal(t, []v1alpha1.TagValidationRule{{
+		RuleName:   "Cluster DC0_C0 tag k8s-zone zone-a",
+		EntityType: "Cluster",
+		EntityName: "DC0_C0",
+		Tag:        "k8s-zone",
+		TagName:    "zone-a",
+	}}, spec.TagValidationRules)
+
+	assert.Len(t, spec.ComputeResourceRules, 1)
+
+	// the snapshot is a known-good baseline, so validating it against the same vCenter succeeds
+	spec.Auth.Account = &vcSim.Account
+	resp := validate.Validate(ctx, *spec, logr.Logger{})
+	for _, err := range resp.ValidationRuleErrors {
+		assert.NoError(t, err)
+	}
+	for _, r := range resp.ValidationRuleResults {
+		assert.Equal(t, vapi.ValidationSucceeded, *r.State, r.Condition.ValidationRule, r.Condition.Failures)
+	}
+
+	// datastores and networks are captured along with the chosen entities
+	spec, err = Snapshot(ctx, driver, Options{
+		Entities: []Entity{
+			{EntityType: "Cluster", EntityName: vcSim.Options.Cluster},
+			{EntityType: "Datastore", EntityName: "LocalDS_0"},
+		},
+		Clusters: []string{vcSim.Options.Cluster},
+	}, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entities = entities[:0]
+	for _, r := range spec.PrivilegeValidationRules {
+		entities = append(entities, r.EntityType+"/"+r.EntityName)
+	}
+	assert.NotContains(t, entities, "Datacenter/DC0")
+	assert.Contains(t, entities, "Cluster/DC0_C0")
+	assert.Contains(t, entities, "Datastore/LocalDS_0")
+	assert.Contains(t, entities, "Datastore/LocalDS_1")
+	assert.Contains(t, entities, "Network/VM Network")
+	assert.Contains(t, entities, "Distributed Port Group/DC0_DVPG0")
+	assert.Len(t, slices.DeleteFunc(slices.Clone(entities), func(e string) bool { return e != "Datastore/LocalDS_0" }), 1)
+}
+
+func TestCommonServers(t *testing.T) {
+	testCases := []struct {
+		name        string
+		hostServers map[string][]string
+		expected    []string
+		ok          bool
+	}{
+		{
+			name: "same servers in any order",
+			hostServers: map[string][]string{
+				"host1": {"ntp2.example.com", "ntp1.example.com"},
+				"host2": {"ntp1.example.com", "ntp2.example.com"},
+			},
+			expected: []string{"ntp1.example.com", "ntp2.example.com"},
+			ok:       true,
+		},
+		{
+			name: "different servers",
+			hostServers: map[string][]string{
+				"host1": {"ntp1.example.com"},
+				"host2": {"ntp2.example.com"},
+			},
+		},
+		{
+			name: "host without servers",
+			hostServers: map[string][]string{
+				"host1": {"ntp1.example.com"},
+				"host2": {},
+			},
+		},
+		{
+			name: "no hosts",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			servers, ok := commonServers(tc.hostServers)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, servers)
+		})
+	}
+}
+
+func TestCapacityRequirement(t *testing.T) {
+	usage := &computeresources.Usage{
+		CPU:     computeresources.ResourceUsage{Free: 10000},
+		Memory:  computeresources.ResourceUsage{Free: 10 << 30},
+		Storage: computeresources.ResourceUsage{Free: 100 << 30},
+	}
+	requirement, ok := capacityRequirement(usage, 0.5)
+	assert.True(t, ok)
+	assert.Equal(t, v1alpha1.NodepoolResourceRequirement{
+		Name:          "snapshot",
+		NumberOfNodes: 1,
+		CPU:           "5000MHz",
+		Memory:        "5120Mi",
+		DiskSpace:     "51200Mi",
+	}, requirement)
+
+	usage.Storage.Free = 0
+	_, ok = capacityRequirement(usage, 0.5)
+	assert.False(t, ok)
+}
@@ -0,0 +1,134 @@
+package validate
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/validator-labs/validator/pkg/types"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter/entity"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+// ruleScope defines the vCenter entities a rule validates
+type ruleScope struct {
+	// entities are the names of the entities the rule validates
+	entities []string
+
+	// clusters are the names of the clusters all of whose hosts the rule validates
+	clusters []string
+
+	// roles is set if the rule could be affected by a change to any role
+	roles bool
+
+	// any is set if the rule could be affected by an event on any entity
+	any bool
+}
+
+// matches reports whether an event occurred on an entity within the scope
+func (s ruleScope) matches(e vcenter.Event) bool {
+	if s.any || s.roles && slices.Contains(vsphere.RoleEventTypes, e.Type) {
+		return true
+	}
+	if e.Entity != "" && slices.Contains(s.entities, e.Entity) {
+		return true
+	}
+	return e.Cluster != "" && slices.Contains(s.clusters, e.Cluster)
+}
+
+// hostScope returns the scope of a rule that validates either the listed hosts or every host in a cluster
+func hostScope(clusterName string, hosts []string) ruleScope {
+	if len(hosts) > 0 {
+		return ruleScope{entities: hosts}
+	}
+	return ruleScope{entities: []string{clusterName}, clusters: []string{clusterName}}
+}
+
+// ruleScopes returns the scope of each rule in a VsphereValidatorSpec, in the order Validate reconciles them
+func ruleScopes(spec v1alpha1.VsphereValidatorSpec) []ruleScope {
+	scopes := make([]ruleScope, 0, spec.ResultCount())
+	for _, r := range spec.NTPValidationRules {
+		scopes = append(scopes, hostScope(r.ClusterName, r.Hosts))
+	}
+	for _, r := range spec.PrivilegeValidationRules {
+		// Roles are not bound to an entity, so a role change could affect any privilege rule
+		scopes = append(scopes, ruleScope{entities: []string{r.EntityName, r.ClusterName}, roles: true})
+	}
+	for _, r := range spec.TagValidationRules {
+		scopes = append(scopes, ruleScope{entities: []string{r.EntityName, r.ClusterName}})
+	}
+	for _, r := range spec.ComputeResourceRules {
+		switch entity.Map[r.Scope] {
+		case entity.Host:
+			scopes = append(scopes, ruleScope{entities: []string{r.EntityName}})
+		case entity.Cluster:
+			scopes = append(scopes, ruleScope{entities: []string{r.EntityName}, clusters: []string{r.EntityName}})
+		default:
+			scopes = append(scopes, ruleScope{entities: []string{r.EntityName, r.ClusterName}, clusters: []string{r.ClusterName}})
+		}
+	}
+	for range spec.TopologyValidationRules {
+		// Zone tags may be carried by any cluster or host in the validated datacenters
+		scopes = append(scopes, ruleScope{any: true})
+	}
+	for _, r := range spec.HostDNSValidationRules {
+		scopes = append(scopes, hostScope(r.ClusterName, r.Hosts))
+	}
+	for _, r := range spec.HostNetworkRules {
+		scopes = append(scopes, hostScope(r.ClusterName, r.Hosts))
+	}
+	for range spec.IPPoolValidationRules {
+		scopes = append(scopes, ruleScope{})
+	}
+	for range spec.FolderValidationRules {
+		scopes = append(scopes, ruleScope{})
+	}
+	for range spec.ResourcePoolValidationRules {
+		scopes = append(scopes, ruleScope{})
+	}
+	for range spec.DRSRuleValidationRules {
+		scopes = append(scopes, ruleScope{})
+	}
+	for _, r := range spec.VSANValidationRules {
+		scopes = append(scopes, hostScope(r.ClusterName, nil))
+	}
+	for _, r := range spec.CertificateValidationRules {
+		scopes = append(scopes, hostScope(r.ClusterName, r.Hosts))
+	}
+	for _, r := range spec.LicenseValidationRules {
+		scopes = append(scopes, hostScope(r.ClusterName, r.Hosts))
+	}
+	for range spec.SupervisorValidationRules {
+		scopes = append(scopes, ruleScope{})
+	}
+	for _, r := range spec.NSXSegmentValidationRules {
+		scopes = append(scopes, hostScope(r.ClusterName, nil))
+	}
+	return scopes
+}
+
+// AttachEvents appends the vCenter events that triggered re-validation to the failures of the failing rules they could
+// affect, i.e., rules of a validation type the event affects whose entity, cluster or hosts the event occurred on.
+func AttachEvents(resp *types.ValidationResponse, spec v1alpha1.VsphereValidatorSpec, events []vcenter.Event) {
+	scopes := ruleScopes(spec)
+	if len(scopes) != len(resp.ValidationRuleResults) {
+		// Validation failed before the rules were reconciled
+		return
+	}
+	for i, vrr := range resp.ValidationRuleResults {
+		if vrr == nil || vrr.Condition == nil || vrr.Condition.Status != corev1.ConditionFalse {
+			continue
+		}
+		for _, e := range events {
+			if !slices.Contains(vsphere.EventValidationTypes[e.Type], vrr.Condition.ValidationType) {
+				continue
+			}
+			if scopes[i].matches(e) {
+				vrr.Condition.Failures = append(vrr.Condition.Failures, "Triggered by "+e.String())
+			}
+		}
+	}
+}
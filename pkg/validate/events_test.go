@@ -0,0 +1,90 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	"github.com/validator-labs/validator/pkg/types"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+)
+
+func TestAttachEvents(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	events := []vcenter.Event{
+		{
+			Type:        "PermissionRemovedEvent",
+			UserName:    "VSPHERE.LOCAL\\bob",
+			CreatedTime: created,
+			Entity:      "DC0_C0",
+			Message:     "Permission rule removed for admin on DC0_C0",
+		},
+		{
+			Type:        "HostConnectionLostEvent",
+			CreatedTime: created,
+			Entity:      "DC0_C0_H0",
+			Cluster:     "DC0_C0",
+		},
+		{
+			Type:        "RoleUpdatedEvent",
+			UserName:    "VSPHERE.LOCAL\\bob",
+			CreatedTime: created,
+			Entity:      "spectro-role",
+		},
+	}
+
+	spec := v1alpha1.VsphereValidatorSpec{
+		NTPValidationRules: []v1alpha1.NTPValidationRule{{RuleName: "ntp", ClusterName: "DC0_C0"}},
+		PrivilegeValidationRules: []v1alpha1.PrivilegeValidationRule{
+			{RuleName: "cluster privileges", EntityType: "cluster", EntityName: "DC0_C0"},
+			{RuleName: "passing privileges", EntityType: "cluster", EntityName: "DC0_C0"},
+			{RuleName: "other cluster privileges", EntityType: "cluster", EntityName: "DC0_C1"},
+		},
+		HostDNSValidationRules: []v1alpha1.HostDNSValidationRule{
+			{RuleName: "other host DNS", ClusterName: "DC0_C0", Hosts: []string{"DC0_C0_H1"}},
+		},
+		FolderValidationRules: []v1alpha1.FolderValidationRule{{RuleName: "folder"}},
+	}
+
+	result := func(validationType string, status corev1.ConditionStatus) *types.ValidationRuleResult {
+		return &types.ValidationRuleResult{
+			Condition: &vapi.ValidationCondition{ValidationType: validationType, Status: status},
+		}
+	}
+	resp := types.ValidationResponse{
+		ValidationRuleResults: []*types.ValidationRuleResult{
+			result(constants.ValidationTypeNTP, corev1.ConditionFalse),
+			result(constants.ValidationTypePrivileges, corev1.ConditionFalse),
+			result(constants.ValidationTypePrivileges, corev1.ConditionTrue),
+			result(constants.ValidationTypePrivileges, corev1.ConditionFalse),
+			result(constants.ValidationTypeHostDNS, corev1.ConditionFalse),
+			result(constants.ValidationTypeFolder, corev1.ConditionFalse),
+		},
+	}
+
+	AttachEvents(&resp, spec, events)
+
+	roleUpdated := "Triggered by RoleUpdatedEvent on spectro-role by VSPHERE.LOCAL\\bob at 2024-05-01T12:00:00Z"
+	expected := [][]string{
+		{"Triggered by HostConnectionLostEvent on DC0_C0_H0 at 2024-05-01T12:00:00Z"},
+		{
+			"Triggered by PermissionRemovedEvent on DC0_C0 by VSPHERE.LOCAL\\bob at 2024-05-01T12:00:00Z: Permission rule removed for admin on DC0_C0",
+			roleUpdated,
+		},
+		nil,
+		{roleUpdated},
+		nil,
+		nil,
+	}
+	for i, vrr := range resp.ValidationRuleResults {
+		if !reflect.DeepEqual(vrr.Condition.Failures, expected[i]) {
+			t.Errorf("result %d: expected failures %v, got %v", i, expected[i], vrr.Condition.Failures)
+		}
+	}
+}
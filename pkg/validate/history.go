@@ -0,0 +1,145 @@
+package validate
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	"github.com/validator-labs/validator/pkg/types"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+)
+
+const (
+	// maxRuleTransitions is the number of state transitions retained per rule.
+	maxRuleTransitions = 10
+
+	// maxRuleFailures is the number of failures retained per rule.
+	maxRuleFailures = 20
+
+	// maxRuleDrift is the number of changes since the previous run retained per rule.
+	maxRuleDrift = 20
+)
+
+// RecordHistory records the outcome of each rule in a validation response in a validator's rule history and returns
+// the updated history. Rules that are not in the response were removed from the spec and are dropped. If validation
+// failed before any rule ran, e.g., because vCenter was unreachable, the history is returned unchanged. How each rule
+// drifted since the previous run, how long it has been failing, and how often it changed state recently are added to
+// its result's details.
+func RecordHistory(history []v1alpha1.RuleHistory, resp *types.ValidationResponse, now time.Time) []v1alpha1.RuleHistory {
+	if initializationFailed(resp) {
+		return history
+	}
+	ts := metav1.NewTime(now.UTC().Truncate(time.Second))
+
+	updated := make([]v1alpha1.RuleHistory, 0, len(resp.ValidationRuleResults))
+	for i, vrr := range resp.ValidationRuleResults {
+		if vrr == nil || vrr.Condition == nil {
+			continue
+		}
+		state, failures := outcome(vrr, resp.ValidationRuleErrors, i)
+
+		var h v1alpha1.RuleHistory
+		j := slices.IndexFunc(history, func(h v1alpha1.RuleHistory) bool {
+			return h.ValidationRule == vrr.Condition.ValidationRule
+		})
+		if j == -1 {
+			h = v1alpha1.RuleHistory{ValidationRule: vrr.Condition.ValidationRule}
+		} else {
+			h = *history[j].DeepCopy()
+			h.Drift = drift(h, state, failures)
+		}
+		h.ValidationType = vrr.Condition.ValidationType
+
+		if h.State != state {
+			h.State = state
+			h.LastTransitionTime = ts
+			h.Transitions = append(h.Transitions, v1alpha1.RuleTransition{State: state, Time: ts})
+			if n := len(h.Transitions); n > maxRuleTransitions {
+				h.Transitions = h.Transitions[n-maxRuleTransitions:]
+			}
+		}
+		h.FailingSince = nil
+		if state == string(vapi.ValidationFailed) {
+			since := h.LastTransitionTime
+			h.FailingSince = &since
+		}
+		h.Failures = failures
+
+		vrr.Condition.Details = append(vrr.Condition.Details, details(h)...)
+		updated = append(updated, h)
+	}
+	return updated
+}
+
+// initializationFailed reports whether validation failed before any rule ran, in which case the response only
+// holds the plugin's initialization result
+func initializationFailed(resp *types.ValidationResponse) bool {
+	for _, vrr := range resp.ValidationRuleResults {
+		if vrr != nil && vrr.Condition != nil && vrr.Condition.ValidationType == constants.PluginCode {
+			return true
+		}
+	}
+	return false
+}
+
+// outcome returns the state and first failures of the i-th rule result, accounting for the error it failed with, if any
+func outcome(vrr *types.ValidationRuleResult, errs []error, i int) (string, []string) {
+	failures := vrr.Condition.Failures
+	failed := vrr.State != nil && *vrr.State == vapi.ValidationFailed
+	limit := maxRuleFailures
+	if i < len(errs) && errs[i] != nil {
+		failed = true
+		limit--
+	}
+	failures = slices.Clone(failures[:min(len(failures), limit)])
+	if limit < maxRuleFailures {
+		failures = append(failures, errs[i].Error())
+	}
+	if failed {
+		return string(vapi.ValidationFailed), failures
+	}
+	return string(vapi.ValidationSucceeded), failures
+}
+
+// drift describes how a rule's state and failures changed since the previous run
+func drift(prev v1alpha1.RuleHistory, state string, failures []string) []string {
+	changes := make([]string, 0)
+	if prev.State != state {
+		changes = append(changes, fmt.Sprintf("state changed from %s to %s", prev.State, state))
+	}
+	for _, f := range failures {
+		if !slices.Contains(prev.Failures, f) {
+			changes = append(changes, "new failure: "+f)
+		}
+	}
+	for _, f := range prev.Failures {
+		if !slices.Contains(failures, f) {
+			changes = append(changes, "resolved failure: "+f)
+		}
+	}
+	if n := len(changes); n > maxRuleDrift {
+		changes = append(changes[:maxRuleDrift-1], fmt.Sprintf("%d more changes", n-maxRuleDrift+1))
+	}
+	return changes
+}
+
+// details summarizes a rule's history for its result's details. The summary only changes when the history does, so
+// that the result is not rewritten on every run.
+func details(h v1alpha1.RuleHistory) []string {
+	lines := make([]string, 0, len(h.Drift)+2)
+	for _, d := range h.Drift {
+		lines = append(lines, "Drift since previous run: "+d)
+	}
+	if h.FailingSince != nil {
+		lines = append(lines, "Failing since "+h.FailingSince.Format(time.RFC3339))
+	}
+	if n := len(h.Transitions) - 1; n > 0 {
+		lines = append(lines, fmt.Sprintf("%d state changes since %s", n, h.Transitions[0].Time.Format(time.RFC3339)))
+	}
+	return lines
+}
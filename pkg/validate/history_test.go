@@ -0,0 +1,201 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+)
+
+func TestRecordHistory(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	response := func(failures []string, err error) *types.ValidationResponse {
+		state := vapi.ValidationSucceeded
+		status := corev1.ConditionTrue
+		if len(failures) > 0 {
+			state = vapi.ValidationFailed
+			status = corev1.ConditionFalse
+		}
+		return &types.ValidationResponse{
+			ValidationRuleResults: []*types.ValidationRuleResult{{
+				Condition: &vapi.ValidationCondition{
+					ValidationRule: "validation-privileges-cluster",
+					ValidationType: constants.ValidationTypePrivileges,
+					Status:         status,
+					Failures:       failures,
+				},
+				State: util.Ptr(state),
+			}},
+			ValidationRuleErrors: []error{err},
+		}
+	}
+	allocate := "user: admin does not have privilege: Datastore.AllocateSpace on entity type: Datastore"
+	browse := "user: admin does not have privilege: Datastore.Browse on entity type: Datastore"
+
+	runs := []struct {
+		name            string
+		resp            *types.ValidationResponse
+		elapsed         time.Duration
+		expectedDrift   []string
+		expectedDetails []string
+		failingSince    *time.Time
+		transitions     int
+	}{
+		{
+			name:        "first run establishes the baseline",
+			resp:        response(nil, nil),
+			transitions: 1,
+		},
+		{
+			name:    "regression",
+			resp:    response([]string{allocate}, nil),
+			elapsed: 2 * time.Minute,
+			expectedDrift: []string{
+				"state changed from Succeeded to Failed",
+				"new failure: " + allocate,
+			},
+			expectedDetails: []string{
+				"Drift since previous run: state changed from Succeeded to Failed",
+				"Drift since previous run: new failure: " + allocate,
+				"Failing since 2024-05-01T12:02:00Z",
+				"1 state changes since 2024-05-01T12:00:00Z",
+			},
+			failingSince: util.Ptr(start.Add(2 * time.Minute)),
+			transitions:  2,
+		},
+		{
+			name:    "failures change while failing",
+			resp:    response([]string{browse}, nil),
+			elapsed: 4 * time.Minute,
+			expectedDrift: []string{
+				"new failure: " + browse,
+				"resolved failure: " + allocate,
+			},
+			expectedDetails: []string{
+				"Drift since previous run: new failure: " + browse,
+				"Drift since previous run: resolved failure: " + allocate,
+				"Failing since 2024-05-01T12:02:00Z",
+				"1 state changes since 2024-05-01T12:00:00Z",
+			},
+			failingSince: util.Ptr(start.Add(2 * time.Minute)),
+			transitions:  2,
+		},
+		{
+			name:    "errors are failures",
+			resp:    response(nil, errors.New("vCenter unreachable")),
+			elapsed: 6 * time.Minute,
+			expectedDrift: []string{
+				"new failure: vCenter unreachable",
+				"resolved failure: " + browse,
+			},
+			expectedDetails: []string{
+				"Drift since previous run: new failure: vCenter unreachable",
+				"Drift since previous run: resolved failure: " + browse,
+				"Failing since 2024-05-01T12:02:00Z",
+				"1 state changes since 2024-05-01T12:00:00Z",
+			},
+			failingSince: util.Ptr(start.Add(2 * time.Minute)),
+			transitions:  2,
+		},
+		{
+			name:    "recovery",
+			resp:    response(nil, nil),
+			elapsed: 8 * time.Minute,
+			expectedDrift: []string{
+				"state changed from Failed to Succeeded",
+				"resolved failure: vCenter unreachable",
+			},
+			expectedDetails: []string{
+				"Drift since previous run: state changed from Failed to Succeeded",
+				"Drift since previous run: resolved failure: vCenter unreachable",
+				"2 state changes since 2024-05-01T12:00:00Z",
+			},
+			transitions: 3,
+		},
+		{
+			name:            "steady state",
+			resp:            response(nil, nil),
+			elapsed:         10 * time.Minute,
+			expectedDrift:   []string{},
+			expectedDetails: []string{"2 state changes since 2024-05-01T12:00:00Z"},
+			transitions:     3,
+		},
+	}
+
+	var history []v1alpha1.RuleHistory
+	for _, run := range runs {
+		history = RecordHistory(history, run.resp, start.Add(run.elapsed))
+		if len(history) != 1 {
+			t.Fatalf("%s: expected 1 rule in history, got %d", run.name, len(history))
+		}
+		h := history[0]
+		if !reflect.DeepEqual(h.Drift, run.expectedDrift) {
+			t.Errorf("%s: expected drift %v, got %v", run.name, run.expectedDrift, h.Drift)
+		}
+		if details := run.resp.ValidationRuleResults[0].Condition.Details; !reflect.DeepEqual(details, run.expectedDetails) {
+			t.Errorf("%s: expected details %v, got %v", run.name, run.expectedDetails, details)
+		}
+		if (h.FailingSince == nil) != (run.failingSince == nil) ||
+			(h.FailingSince != nil && !h.FailingSince.Time.Equal(*run.failingSince)) {
+			t.Errorf("%s: expected failing since %v, got %v", run.name, run.failingSince, h.FailingSince)
+		}
+		if len(h.Transitions) != run.transitions {
+			t.Errorf("%s: expected %d transitions, got %d", run.name, run.transitions, len(h.Transitions))
+		}
+	}
+
+	// transitions are bounded
+	for i := 0; i < 2*maxRuleTransitions; i++ {
+		resp := response(nil, nil)
+		if i%2 == 0 {
+			resp = response([]string{allocate}, nil)
+		}
+		history = RecordHistory(history, resp, start.Add(time.Hour+time.Duration(i)*time.Minute))
+	}
+	if n := len(history[0].Transitions); n != maxRuleTransitions {
+		t.Errorf("expected %d transitions, got %d", maxRuleTransitions, n)
+	}
+
+	// a pass that failed before any rule ran leaves the history unchanged
+	failed := &types.ValidationResponse{
+		ValidationRuleResults: []*types.ValidationRuleResult{buildValidationResult()},
+		ValidationRuleErrors:  []error{errors.New("failed to create vCenter driver")},
+	}
+	unchanged := RecordHistory(history, failed, start.Add(90*time.Minute))
+	if !reflect.DeepEqual(unchanged, history) {
+		t.Errorf("expected unchanged history %v, got %v", history, unchanged)
+	}
+
+	// failures and drift are bounded, and errors are retained
+	many := make([]string, 0, 2*maxRuleFailures)
+	for i := 0; i < 2*maxRuleFailures; i++ {
+		many = append(many, fmt.Sprintf("failure %d", i))
+	}
+	history = RecordHistory(history, response(many, errors.New("vCenter unreachable")), start.Add(100*time.Minute))
+	if n := len(history[0].Failures); n != maxRuleFailures {
+		t.Errorf("expected %d failures, got %d", maxRuleFailures, n)
+	}
+	if last := history[0].Failures[maxRuleFailures-1]; last != "vCenter unreachable" {
+		t.Errorf("expected error to be retained as the last failure, got %s", last)
+	}
+	if n := len(history[0].Drift); n != maxRuleDrift {
+		t.Errorf("expected %d drift entries, got %d", maxRuleDrift, n)
+	}
+
+	// rules that are no longer validated are dropped
+	history = RecordHistory(history, &types.ValidationResponse{}, start.Add(2*time.Hour))
+	if len(history) != 0 {
+		t.Errorf("expected empty history, got %v", history)
+	}
+}
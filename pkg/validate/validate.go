@@ -15,11 +15,24 @@ import (
 	"github.com/validator-labs/validator/pkg/types"
 
 	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter/entity"
 	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/certificate"
 	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/computeresources"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/drs"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/folder"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/hostdns"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/hostnetwork"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/ippool"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/license"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/nsx"
 	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/ntp"
 	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/privileges"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/resourcepool"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/supervisor"
 	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/tags"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/topology"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/validators/vsan"
 	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
 )
 
@@ -58,6 +71,11 @@ func Validate(ctx context.Context, spec v1alpha1.VsphereValidatorSpec, log logr.
 		return resp
 	}
 
+	// Snapshot the inventory once so that rules share lookups rather than each querying vCenter
+	if err := driver.LoadInventory(ctx, targetsVMs(spec)); err != nil {
+		log.Error(err, "failed to load vCenter inventory; falling back to per-rule lookups")
+	}
+
 	tagsManager := vtags.NewManager(driver.RestClient)
 
 	// Get the current user
@@ -126,6 +144,150 @@ func Validate(ctx context.Context, spec v1alpha1.VsphereValidatorSpec, log logr.
 		}
 	}
 
+	// Topology validation rules
+	topologyValidationService := topology.NewValidationService(log, driver)
+	for _, rule := range spec.TopologyValidationRules {
+		vrr, err := topologyValidationService.ReconcileTopologyRule(rule)
+		if err != nil {
+			log.Error(err, "failed to reconcile topology validation rule")
+		}
+		vrr.Finalize(err)
+		resp.AddResult(vrr, err)
+		log.Info("Validated topology", "rule", rule.Name())
+	}
+
+	// Host DNS validation rules
+	hostDNSValidationService := hostdns.NewValidationService(log, driver, spec.Datacenter)
+	for _, rule := range spec.HostDNSValidationRules {
+		vrr, err := hostDNSValidationService.ReconcileHostDNSRule(rule, finder)
+		if err != nil {
+			log.Error(err, "failed to reconcile host DNS validation rule")
+		}
+		vrr.Finalize(err)
+		resp.AddResult(vrr, err)
+		log.Info("Validated host DNS", "rule", rule.Name())
+	}
+
+	// Host network validation rules
+	hostNetworkValidationService := hostnetwork.NewValidationService(log, driver, spec.Datacenter)
+	for _, rule := range spec.HostNetworkRules {
+		vrr, err := hostNetworkValidationService.ReconcileHostNetworkRule(rule, finder)
+		if err != nil {
+			log.Error(err, "failed to reconcile host network validation rule")
+		}
+		vrr.Finalize(err)
+		resp.AddResult(vrr, err)
+		log.Info("Validated host network", "rule", rule.Name())
+	}
+
+	// IP pool validation rules
+	ipPoolValidationService := ippool.NewValidationService(log, driver, spec.Datacenter)
+	for _, rule := range spec.IPPoolValidationRules {
+		vrr, err := ipPoolValidationService.ReconcileIPPoolRule(rule, finder)
+		if err != nil {
+			log.Error(err, "failed to reconcile IP pool validation rule")
+		}
+		vrr.Finalize(err)
+		resp.AddResult(vrr, err)
+		log.Info("Validated IP pool", "rule", rule.Name())
+	}
+
+	// Folder validation rules
+	folderValidationService := folder.NewValidationService(log, driver, spec.Datacenter, username, authManager)
+	for _, rule := range spec.FolderValidationRules {
+		vrr, err := folderValidationService.ReconcileFolderRule(rule, finder)
+		if err != nil {
+			log.Error(err, "failed to reconcile folder validation rule")
+		}
+		vrr.Finalize(err)
+		resp.AddResult(vrr, err)
+		log.Info("Validated folder", "rule", rule.Name())
+	}
+
+	// Resource pool validation rules
+	resourcePoolValidationService := resourcepool.NewValidationService(log, driver, spec.Datacenter)
+	for _, rule := range spec.ResourcePoolValidationRules {
+		vrr, err := resourcePoolValidationService.ReconcileResourcePoolRule(rule, finder)
+		if err != nil {
+			log.Error(err, "failed to reconcile resource pool validation rule")
+		}
+		vrr.Finalize(err)
+		resp.AddResult(vrr, err)
+		log.Info("Validated resource pool", "rule", rule.Name())
+	}
+
+	// DRS rule validation rules
+	drsValidationService := drs.NewValidationService(log, driver, spec.Datacenter)
+	for _, rule := range spec.DRSRuleValidationRules {
+		vrr, err := drsValidationService.ReconcileDRSRule(rule, finder)
+		if err != nil {
+			log.Error(err, "failed to reconcile DRS rule validation rule")
+		}
+		vrr.Finalize(err)
+		resp.AddResult(vrr, err)
+		log.Info("Validated DRS rule", "rule", rule.Name())
+	}
+
+	// vSAN validation rules
+	vsanValidationService := vsan.NewValidationService(log, driver, spec.Datacenter)
+	for _, rule := range spec.VSANValidationRules {
+		vrr, err := vsanValidationService.ReconcileVSANRule(rule, finder)
+		if err != nil {
+			log.Error(err, "failed to reconcile vSAN validation rule")
+		}
+		vrr.Finalize(err)
+		resp.AddResult(vrr, err)
+		log.Info("Validated vSAN", "rule", rule.Name())
+	}
+
+	// Certificate validation rules
+	certificateValidationService := certificate.NewValidationService(log, driver, spec.Datacenter)
+	for _, rule := range spec.CertificateValidationRules {
+		vrr, err := certificateValidationService.ReconcileCertificateRule(rule, finder)
+		if err != nil {
+			log.Error(err, "failed to reconcile certificate validation rule")
+		}
+		vrr.Finalize(err)
+		resp.AddResult(vrr, err)
+		log.Info("Validated certificates", "rule", rule.Name())
+	}
+
+	// License validation rules
+	licenseValidationService := license.NewValidationService(log, driver, spec.Datacenter)
+	for _, rule := range spec.LicenseValidationRules {
+		vrr, err := licenseValidationService.ReconcileLicenseRule(rule, finder)
+		if err != nil {
+			log.Error(err, "failed to reconcile license validation rule")
+		}
+		vrr.Finalize(err)
+		resp.AddResult(vrr, err)
+		log.Info("Validated licenses", "rule", rule.Name())
+	}
+
+	// Supervisor validation rules
+	supervisorValidationService := supervisor.NewValidationService(log, driver, spec.Datacenter)
+	for _, rule := range spec.SupervisorValidationRules {
+		vrr, err := supervisorValidationService.ReconcileSupervisorRule(rule, finder)
+		if err != nil {
+			log.Error(err, "failed to reconcile Supervisor validation rule")
+		}
+		vrr.Finalize(err)
+		resp.AddResult(vrr, err)
+		log.Info("Validated Supervisor", "rule", rule.Name())
+	}
+
+	// NSX segment validation rules
+	nsxValidationService := nsx.NewValidationService(log, driver, spec.Datacenter)
+	for _, rule := range spec.NSXSegmentValidationRules {
+		vrr, err := nsxValidationService.ReconcileNSXSegmentRule(rule, finder)
+		if err != nil {
+			log.Error(err, "failed to reconcile NSX segment validation rule")
+		}
+		vrr.Finalize(err)
+		resp.AddResult(vrr, err)
+		log.Info("Validated NSX segment", "rule", rule.Name())
+	}
+
 	return resp
 }
 
@@ -138,3 +300,14 @@ func buildValidationResult() *types.ValidationRuleResult {
 
 	return &types.ValidationRuleResult{Condition: &latestCondition, State: &state}
 }
+
+// targetsVMs reports whether any privilege rule targets a VM or vApp, so that the inventory snapshot must include them
+func targetsVMs(spec v1alpha1.VsphereValidatorSpec) bool {
+	for _, rule := range spec.PrivilegeValidationRules {
+		switch entity.Map[rule.EntityType] {
+		case entity.VirtualMachine, entity.VirtualApp:
+			return true
+		}
+	}
+	return false
+}
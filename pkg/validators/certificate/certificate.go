@@ -0,0 +1,153 @@
+// Package certificate handles vCenter and ESXi host certificate validation rule reconciliation.
+package certificate
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/find"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	vapiconstants "github.com/validator-labs/validator/pkg/constants"
+	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+const day = 24 * time.Hour
+
+// ValidationService is a service that validates certificate rules
+type ValidationService struct {
+	log        logr.Logger
+	driver     *vsphere.VCenterDriver
+	datacenter string
+	now        func() time.Time
+}
+
+// NewValidationService creates a new ValidationService
+func NewValidationService(log logr.Logger, driver *vsphere.VCenterDriver, datacenter string) *ValidationService {
+	return &ValidationService{
+		log:        log,
+		driver:     driver,
+		datacenter: datacenter,
+		now:        time.Now,
+	}
+}
+
+func buildValidationResult(rule v1alpha1.CertificateValidationRule) *types.ValidationRuleResult {
+	state := vapi.ValidationSucceeded
+	validationType := constants.ValidationTypeCertificate
+
+	validationRule := fmt.Sprintf("%s-%s-%s", vapiconstants.ValidationRulePrefix, validationType, rule.Name())
+
+	latestCondition := vapi.DefaultValidationCondition()
+	latestCondition.Message = "All vCenter and host certificates are valid"
+	latestCondition.ValidationRule = util.Sanitize(validationRule)
+	latestCondition.ValidationType = validationType
+
+	return &types.ValidationRuleResult{Condition: &latestCondition, State: &state}
+}
+
+// ReconcileCertificateRule reconciles a certificate rule
+func (s *ValidationService) ReconcileCertificateRule(rule v1alpha1.CertificateValidationRule, finder *find.Finder) (*types.ValidationRuleResult, error) {
+	vr := buildValidationResult(rule)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	vcCerts, err := s.driver.GetVCenterCertificates(ctx)
+	if err != nil {
+		return vr, err
+	}
+	hostCerts, notFound, unavailable, err := s.driver.GetHostCertificates(ctx, finder, s.datacenter, rule.ClusterName, rule.Hosts)
+	if err != nil {
+		return vr, err
+	}
+
+	// certificates must remain valid until the deadline
+	now := s.now()
+	deadline := now.Add(time.Duration(rule.MinValidDays) * day)
+
+	failures := make([]string, 0)
+	for _, cert := range vcCerts {
+		if problem := validateValidity(cert, now, deadline); problem != "" {
+			failures = append(failures, fmt.Sprintf("vCenter: %s: %s", cert.Subject, problem))
+		}
+	}
+	for _, host := range notFound {
+		failures = append(failures, fmt.Sprintf("Host: %s: not found", host))
+	}
+	for _, host := range slices.Sorted(maps.Keys(unavailable)) {
+		failures = append(failures, fmt.Sprintf("Host: %s: certificate unavailable: %s", host, unavailable[host]))
+	}
+	for _, hc := range hostCerts {
+		problems := make([]string, 0)
+		if problem := validateValidity(hc.Certificate, now, deadline); problem != "" {
+			problems = append(problems, problem)
+		}
+		if problem := validateIssuer(hc.Certificate, rule.ExpectedIssuers); problem != "" {
+			problems = append(problems, problem)
+		}
+		if len(problems) > 0 {
+			failures = append(failures, fmt.Sprintf("Host: %s: %s", hc.Host, strings.Join(problems, "; ")))
+		}
+	}
+
+	if len(failures) > 0 {
+		vr.State = util.Ptr(vapi.ValidationFailed)
+		vr.Condition.Failures = failures
+		vr.Condition.Message = "One or more vCenter or host certificates are invalid or expire soon"
+		vr.Condition.Status = corev1.ConditionFalse
+	}
+
+	return vr, nil
+}
+
+// validateValidity ensures that a certificate is valid now and remains valid until the deadline
+func validateValidity(cert vcenter.Certificate, now, deadline time.Time) string {
+	notAfter := cert.NotAfter.UTC().Format(time.RFC3339)
+	switch {
+	case now.Before(cert.NotBefore):
+		return fmt.Sprintf("certificate is not valid before %s", cert.NotBefore.UTC().Format(time.RFC3339))
+	case !now.Before(cert.NotAfter):
+		return fmt.Sprintf("certificate expired at %s", notAfter)
+	case deadline.After(cert.NotAfter):
+		days := int(cert.NotAfter.Sub(now) / day)
+		return fmt.Sprintf("certificate expires at %s, in %d day(s)", notAfter, days)
+	}
+	return ""
+}
+
+// validateIssuer ensures that a certificate was issued by one of the expected issuers
+func validateIssuer(cert vcenter.Certificate, expectedIssuers []string) string {
+	if len(expectedIssuers) == 0 {
+		return ""
+	}
+	issuer := normalizeDN(cert.Issuer)
+	for _, expected := range expectedIssuers {
+		if strings.Contains(issuer, normalizeDN(expected)) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("certificate issuer %s is not one of %v", cert.Issuer, expectedIssuers)
+}
+
+// normalizeDN lowercases a distinguished name and removes whitespace between its attributes,
+// since vCenter and crypto/x509 format distinguished names differently
+func normalizeDN(dn string) string {
+	parts := strings.Split(dn, ",")
+	for i, p := range parts {
+		parts[i] = strings.TrimSpace(p)
+	}
+	return strings.ToLower(strings.Join(parts, ","))
+}
@@ -0,0 +1,86 @@
+package certificate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+func TestValidateValidity(t *testing.T) {
+	now := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	cert := vcenter.Certificate{
+		NotBefore: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:  time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		minDays  int
+		expected string
+	}{
+		{
+			name:     "Pass",
+			now:      now,
+			minDays:  30,
+			expected: "",
+		},
+		{
+			name:     "Fail expiring within window",
+			now:      now,
+			minDays:  31,
+			expected: "certificate expires at 2024-07-01T00:00:00Z, in 30 day(s)",
+		},
+		{
+			name:     "Fail expired",
+			now:      cert.NotAfter,
+			expected: "certificate expired at 2024-07-01T00:00:00Z",
+		},
+		{
+			name:     "Fail not yet valid",
+			now:      cert.NotBefore.Add(-time.Second),
+			expected: "certificate is not valid before 2024-01-01T00:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deadline := tt.now.Add(time.Duration(tt.minDays) * day)
+			assert.Equal(t, tt.expected, validateValidity(cert, tt.now, deadline))
+		})
+	}
+}
+
+func TestValidateIssuer(t *testing.T) {
+	cert := vcenter.Certificate{Issuer: "O=vcsa.example.com, C=US, DC=local, DC=vsphere, CN=CA"}
+
+	tests := []struct {
+		name     string
+		expected []string
+		problem  string
+	}{
+		{
+			name:    "Pass without expected issuers",
+			problem: "",
+		},
+		{
+			name:     "Pass with VMCA ignoring case and whitespace",
+			expected: []string{"o=vcsa.example.com,c=US", "DC=local,DC=vsphere,CN=CA"},
+			problem:  "",
+		},
+		{
+			name:     "Fail with unexpected issuer",
+			expected: []string{"CN=Corp Issuing CA"},
+			problem:  "certificate issuer O=vcsa.example.com, C=US, DC=local, DC=vsphere, CN=CA is not one of [CN=Corp Issuing CA]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.problem, validateIssuer(cert, tt.expected))
+		})
+	}
+}
@@ -127,30 +127,11 @@ func (c *ValidationService) ReconcileComputeResourceValidationRule(rule v1alpha1
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	var res *Usage
-	switch e := entity.Map[rule.Scope]; e {
-	case entity.Cluster:
-		res, err = clusterUsage(ctx, rule, finder)
-	case entity.ResourcePool:
-		res, err = resourcePoolUsage(ctx, rule, finder, driver)
-	case entity.Host:
-		res, err = hostUsage(ctx, rule, finder)
-	default:
-		err = fmt.Errorf("unsupported scope: %s", rule.Scope)
-	}
+	res, err := GetUsage(ctx, rule, finder, driver)
 	if err != nil {
 		return vr, err
 	}
 
-	res.CPU.Free = res.CPU.Capacity - res.CPU.Used
-	res.CPU.summarize(ghz)
-
-	res.Memory.Free = res.Memory.Capacity - res.Memory.Used
-	res.Memory.summarize(size)
-
-	res.Storage.Used = res.Storage.Capacity - res.Storage.Free
-	res.Storage.summarize(size)
-
 	freeCPU := convertStringToQuantity(sanitizeStrUnits(res.CPU.Summary.Free, "cpu"))
 	freeMemory := convertStringToQuantity(sanitizeStrUnits(res.Memory.Summary.Free, "memory"))
 	freeStorage := convertStringToQuantity(sanitizeStrUnits(res.Storage.Summary.Free, "storage"))
@@ -173,11 +154,41 @@ func (c *ValidationService) ReconcileComputeResourceValidationRule(rule v1alpha1
 	return vr, nil
 }
 
-func clusterUsage(ctx context.Context, rule v1alpha1.ComputeResourceRule, finder *find.Finder) (*Usage, error) {
+// GetUsage returns the memory, cpu and storage usage of a compute resource rule's scope
+func GetUsage(ctx context.Context, rule v1alpha1.ComputeResourceRule, finder *find.Finder, driver *vsphere.VCenterDriver) (*Usage, error) {
+	var res *Usage
+	var err error
+	switch e := entity.Map[rule.Scope]; e {
+	case entity.Cluster:
+		res, err = clusterUsage(ctx, rule, finder, driver)
+	case entity.ResourcePool:
+		res, err = resourcePoolUsage(ctx, rule, finder, driver)
+	case entity.Host:
+		res, err = hostUsage(ctx, rule, finder)
+	default:
+		err = fmt.Errorf("unsupported scope: %s", rule.Scope)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res.CPU.Free = res.CPU.Capacity - res.CPU.Used
+	res.CPU.summarize(ghz)
+
+	res.Memory.Free = res.Memory.Capacity - res.Memory.Used
+	res.Memory.summarize(size)
+
+	res.Storage.Used = res.Storage.Capacity - res.Storage.Free
+	res.Storage.summarize(size)
+
+	return res, nil
+}
+
+func clusterUsage(ctx context.Context, rule v1alpha1.ComputeResourceRule, finder *find.Finder, driver *vsphere.VCenterDriver) (*Usage, error) {
 	var res Usage
 
 	// disk space
-	datastores, hosts, err := clusterResources(ctx, finder, rule.EntityName)
+	datastores, hosts, err := driver.GetClusterResources(ctx, finder, driver.Datacenter, rule.EntityName)
 	if err != nil {
 		return nil, err
 	}
@@ -239,7 +250,7 @@ func resourcePoolUsage(ctx context.Context, rule v1alpha1.ComputeResourceRule, f
 	}
 
 	// disk space
-	datastores, _, err := clusterResources(ctx, finder, rule.ClusterName)
+	datastores, _, err := driver.GetClusterResources(ctx, finder, driver.Datacenter, rule.ClusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -248,31 +259,6 @@ func resourcePoolUsage(ctx context.Context, rule v1alpha1.ComputeResourceRule, f
 	return &res, nil
 }
 
-func clusterResources(ctx context.Context, finder *find.Finder, path string) ([]mo.Datastore, []mo.HostSystem, error) {
-	obj, err := finder.ClusterComputeResource(ctx, path)
-	if err != nil {
-		return nil, nil, err
-	}
-	pc := property.DefaultCollector(obj.Client())
-
-	var cluster mo.ClusterComputeResource
-	if err := pc.RetrieveOne(ctx, obj.Reference(), []string{"datastore", "host"}, &cluster); err != nil {
-		return nil, nil, err
-	}
-
-	var datastores []mo.Datastore
-	if err := pc.Retrieve(ctx, cluster.Datastore, []string{"summary"}, &datastores); err != nil {
-		return nil, nil, err
-	}
-
-	var hosts []mo.HostSystem
-	if err = pc.Retrieve(ctx, cluster.Host, []string{"summary"}, &hosts); err != nil {
-		return nil, nil, err
-	}
-
-	return datastores, hosts, nil
-}
-
 func addHostUsage(res *Usage, host mo.HostSystem) {
 	res.CPU.Capacity += int64(int32(host.Summary.Hardware.NumCpuCores) * host.Summary.Hardware.CpuMhz)
 	res.CPU.Used += int64(host.Summary.QuickStats.OverallCpuUsage)
@@ -0,0 +1,130 @@
+// Package drs handles DRS rule validation rule reconciliation.
+package drs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/find"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	vapiconstants "github.com/validator-labs/validator/pkg/constants"
+	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+// ValidationService is a service that validates DRS rules
+type ValidationService struct {
+	log        logr.Logger
+	driver     *vsphere.VCenterDriver
+	datacenter string
+}
+
+// NewValidationService creates a new ValidationService
+func NewValidationService(log logr.Logger, driver *vsphere.VCenterDriver, datacenter string) *ValidationService {
+	return &ValidationService{
+		log:        log,
+		driver:     driver,
+		datacenter: datacenter,
+	}
+}
+
+func buildValidationResult(rule v1alpha1.DRSRuleValidationRule) *types.ValidationRuleResult {
+	state := vapi.ValidationSucceeded
+	validationType := constants.ValidationTypeDRSRule
+
+	validationRule := fmt.Sprintf("%s-%s-%s", vapiconstants.ValidationRulePrefix, validationType, rule.Name())
+
+	latestCondition := vapi.DefaultValidationCondition()
+	latestCondition.Message = fmt.Sprintf("DRS rule %s is enabled and has the expected configuration", rule.DRSRuleName)
+	latestCondition.ValidationRule = util.Sanitize(validationRule)
+	latestCondition.ValidationType = validationType
+
+	return &types.ValidationRuleResult{Condition: &latestCondition, State: &state}
+}
+
+// ReconcileDRSRule reconciles a DRS rule validation rule
+func (s *ValidationService) ReconcileDRSRule(rule v1alpha1.DRSRuleValidationRule, finder *find.Finder) (*types.ValidationRuleResult, error) {
+	vr := buildValidationResult(rule)
+
+	if _, err := path.Match(rule.VMNamePattern, ""); err != nil {
+		return vr, fmt.Errorf("invalid VM name pattern %s: %w", rule.VMNamePattern, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	failures := make([]string, 0)
+
+	cfg, err := s.driver.GetClusterDRSConfig(ctx, finder, s.datacenter, rule.ClusterName)
+	if err != nil {
+		var notFoundErr *find.NotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return vr, err
+		}
+		failures = append(failures, fmt.Sprintf("cluster %s not found", rule.ClusterName))
+	} else {
+		failures = append(failures, validateDRSConfig(rule, cfg)...)
+	}
+
+	if len(failures) > 0 {
+		vr.State = util.Ptr(vapi.ValidationFailed)
+		vr.Condition.Failures = failures
+		vr.Condition.Message = fmt.Sprintf("DRS rule %s is missing, disabled, or does not have the expected configuration", rule.DRSRuleName)
+		vr.Condition.Status = corev1.ConditionFalse
+	}
+
+	return vr, nil
+}
+
+// validateDRSConfig ensures that DRS is enabled on the cluster and that the DRS rule exists, is enabled,
+// and has the expected type, enforcement and members
+func validateDRSConfig(rule v1alpha1.DRSRuleValidationRule, cfg *vcenter.ClusterDRSConfig) []string {
+	failures := make([]string, 0)
+
+	if !cfg.DRSEnabled {
+		failures = append(failures, fmt.Sprintf("DRS is disabled on cluster %s", rule.ClusterName))
+	}
+
+	idx := slices.IndexFunc(cfg.Rules, func(r vcenter.DRSRule) bool { return r.Name == rule.DRSRuleName })
+	if idx == -1 {
+		return append(failures, fmt.Sprintf("DRS rule %s not found in cluster %s", rule.DRSRuleName, rule.ClusterName))
+	}
+	drsRule := cfg.Rules[idx]
+
+	if string(drsRule.Type) != rule.Type {
+		failures = append(failures, fmt.Sprintf("DRS rule %s is a %s rule, expected %s", drsRule.Name, drsRule.Type, rule.Type))
+	}
+	if !drsRule.Enabled {
+		failures = append(failures, fmt.Sprintf("DRS rule %s is disabled", drsRule.Name))
+	}
+	if rule.Mandatory != nil && drsRule.Mandatory != *rule.Mandatory {
+		failures = append(failures, fmt.Sprintf("DRS rule %s is %s, expected %s", drsRule.Name, enforcement(drsRule.Mandatory), enforcement(*rule.Mandatory)))
+	}
+	if rule.VMNamePattern != "" {
+		for _, vm := range cfg.VMs {
+			if matched, _ := path.Match(rule.VMNamePattern, vm); matched && !slices.Contains(drsRule.VMs, vm) {
+				failures = append(failures, fmt.Sprintf("VM: %s: matches %s but is not included in DRS rule %s", vm, rule.VMNamePattern, drsRule.Name))
+			}
+		}
+	}
+
+	return failures
+}
+
+func enforcement(mandatory bool) string {
+	if mandatory {
+		return "mandatory"
+	}
+	return "preferred"
+}
@@ -0,0 +1,90 @@
+package drs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+func TestValidateDRSConfig(t *testing.T) {
+	cfg := &vcenter.ClusterDRSConfig{
+		DRSEnabled: true,
+		Rules: []vcenter.DRSRule{
+			{
+				Name:      "cp-anti-affinity",
+				Type:      vcenter.DRSRuleTypeVMAntiAffinity,
+				Enabled:   true,
+				Mandatory: true,
+				VMs:       []string{"cluster-cp-1", "cluster-cp-2"},
+			},
+			{
+				Name:      "cp-on-hosts",
+				Type:      vcenter.DRSRuleTypeVMHost,
+				VMs:       []string{"cluster-cp-1"},
+				VMGroup:   "cp-vms",
+				HostGroup: "cp-hosts",
+			},
+		},
+		VMs: []string{"cluster-cp-1", "cluster-cp-2", "cluster-worker-1"},
+	}
+
+	tests := []struct {
+		name     string
+		rule     v1alpha1.DRSRuleValidationRule
+		cfg      *vcenter.ClusterDRSConfig
+		expected []string
+	}{
+		{
+			name: "Pass",
+			rule: v1alpha1.DRSRuleValidationRule{
+				ClusterName:   "cluster",
+				DRSRuleName:   "cp-anti-affinity",
+				Type:          "vmAntiAffinity",
+				Mandatory:     util.Ptr(true),
+				VMNamePattern: "cluster-cp-*",
+			},
+			cfg:      cfg,
+			expected: []string{},
+		},
+		{
+			name: "Fail",
+			rule: v1alpha1.DRSRuleValidationRule{
+				ClusterName:   "cluster",
+				DRSRuleName:   "cp-on-hosts",
+				Type:          "vmAntiAffinity",
+				Mandatory:     util.Ptr(true),
+				VMNamePattern: "cluster-cp-*",
+			},
+			cfg: cfg,
+			expected: []string{
+				"DRS rule cp-on-hosts is a vmHost rule, expected vmAntiAffinity",
+				"DRS rule cp-on-hosts is disabled",
+				"DRS rule cp-on-hosts is preferred, expected mandatory",
+				"VM: cluster-cp-2: matches cluster-cp-* but is not included in DRS rule cp-on-hosts",
+			},
+		},
+		{
+			name: "Fail with DRS disabled and missing rule",
+			rule: v1alpha1.DRSRuleValidationRule{
+				ClusterName: "cluster",
+				DRSRuleName: "missing",
+				Type:        "vmAntiAffinity",
+			},
+			cfg: &vcenter.ClusterDRSConfig{},
+			expected: []string{
+				"DRS is disabled on cluster cluster",
+				"DRS rule missing not found in cluster cluster",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validateDRSConfig(tt.rule, tt.cfg))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Package folder handles folder validation rule reconciliation.
+package folder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	vapiconstants "github.com/validator-labs/validator/pkg/constants"
+	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter/entity"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+const defaultFolderType = "vm"
+
+// folderChildTypes maps each folder type to the managed object type that distinguishes folders of that type
+var folderChildTypes = map[string]string{
+	"vm":        "VirtualMachine",
+	"host":      "ComputeResource",
+	"datastore": "Datastore",
+	"network":   "Network",
+}
+
+// ValidationService is a service that validates folder rules
+type ValidationService struct {
+	log         logr.Logger
+	driver      *vsphere.VCenterDriver
+	datacenter  string
+	authManager *object.AuthorizationManager
+	username    string
+}
+
+// NewValidationService creates a new ValidationService
+func NewValidationService(log logr.Logger, driver *vsphere.VCenterDriver, datacenter, username string, authManager *object.AuthorizationManager) *ValidationService {
+	return &ValidationService{
+		log:         log,
+		driver:      driver,
+		datacenter:  datacenter,
+		authManager: authManager,
+		username:    username,
+	}
+}
+
+func buildValidationResult(rule v1alpha1.FolderValidationRule) *types.ValidationRuleResult {
+	state := vapi.ValidationSucceeded
+	validationType := constants.ValidationTypeFolder
+
+	validationRule := fmt.Sprintf("%s-%s-%s", vapiconstants.ValidationRulePrefix, validationType, rule.Name())
+
+	latestCondition := vapi.DefaultValidationCondition()
+	latestCondition.Message = fmt.Sprintf("Folder %s exists and satisfies all requirements", rule.Path)
+	latestCondition.ValidationRule = util.Sanitize(validationRule)
+	latestCondition.ValidationType = validationType
+
+	return &types.ValidationRuleResult{Condition: &latestCondition, State: &state}
+}
+
+// ReconcileFolderRule reconciles a folder rule
+func (s *ValidationService) ReconcileFolderRule(rule v1alpha1.FolderValidationRule, finder *find.Finder) (*types.ValidationRuleResult, error) {
+	vr := buildValidationResult(rule)
+
+	folderType := rule.Type
+	if folderType == "" {
+		folderType = defaultFolderType
+	}
+	if _, ok := folderChildTypes[folderType]; !ok {
+		return vr, fmt.Errorf("invalid folder type %s", folderType)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	failures := make([]string, 0)
+
+	summary, err := s.driver.GetFolderSummary(ctx, finder, s.datacenter, folderType, rule.Path)
+	if err != nil {
+		var notFoundErr *find.NotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return vr, err
+		}
+		failures = append(failures, fmt.Sprintf("folder %s not found", rule.Path))
+	} else {
+		failures = append(failures, validateFolderSummary(rule, folderType, summary)...)
+
+		if len(rule.Privileges) > 0 {
+			privilegeRule := v1alpha1.PrivilegeValidationRule{
+				EntityType: entity.Folder.String(),
+				EntityName: summary.InventoryPath,
+				Privileges: rule.Privileges,
+			}
+			privilegeFailures, err := s.driver.ValidateUserPrivilegeOnEntities(ctx, s.authManager, s.datacenter, s.username, finder, privilegeRule)
+			if err != nil {
+				return vr, err
+			}
+			failures = append(failures, privilegeFailures...)
+		}
+	}
+
+	if len(failures) > 0 {
+		vr.State = util.Ptr(vapi.ValidationFailed)
+		vr.Condition.Failures = failures
+		vr.Condition.Message = fmt.Sprintf("Folder %s does not exist or does not satisfy all requirements", rule.Path)
+		vr.Condition.Status = corev1.ConditionFalse
+	}
+
+	return vr, nil
+}
+
+// validateFolderSummary ensures that a folder is of the expected type and does not contain too many VMs
+func validateFolderSummary(rule v1alpha1.FolderValidationRule, folderType string, summary *vcenter.FolderSummary) []string {
+	failures := make([]string, 0)
+
+	if !slices.Contains(summary.ChildTypes, folderChildTypes[folderType]) {
+		failures = append(failures, fmt.Sprintf("folder %s is not a %s folder; it may only contain %v", summary.InventoryPath, folderType, summary.ChildTypes))
+	}
+	if rule.MaxVMs != nil && len(summary.VMs) > *rule.MaxVMs {
+		failures = append(failures, fmt.Sprintf("folder %s contains %d VMs %v, expected at most %d", summary.InventoryPath, len(summary.VMs), summary.VMs, *rule.MaxVMs))
+	}
+
+	return failures
+}
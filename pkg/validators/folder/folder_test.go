@@ -0,0 +1,54 @@
+package folder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+func TestValidateFolderSummary(t *testing.T) {
+	summary := &vcenter.FolderSummary{
+		InventoryPath: "/DC0/vm/k8s",
+		ChildTypes:    []string{"Folder", "VirtualMachine", "VirtualApp"},
+		VMs:           []string{"vm1", "vm2"},
+	}
+
+	tests := []struct {
+		name       string
+		rule       v1alpha1.FolderValidationRule
+		folderType string
+		expected   []string
+	}{
+		{
+			name:       "Pass",
+			rule:       v1alpha1.FolderValidationRule{MaxVMs: util.Ptr(2)},
+			folderType: "vm",
+			expected:   []string{},
+		},
+		{
+			name:       "Pass without VM limit",
+			rule:       v1alpha1.FolderValidationRule{},
+			folderType: "vm",
+			expected:   []string{},
+		},
+		{
+			name:       "Fail",
+			rule:       v1alpha1.FolderValidationRule{MaxVMs: util.Ptr(0)},
+			folderType: "host",
+			expected: []string{
+				"folder /DC0/vm/k8s is not a host folder; it may only contain [Folder VirtualMachine VirtualApp]",
+				"folder /DC0/vm/k8s contains 2 VMs [vm1 vm2], expected at most 0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validateFolderSummary(tt.rule, tt.folderType, summary))
+		})
+	}
+}
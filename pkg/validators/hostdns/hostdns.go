@@ -0,0 +1,194 @@
+// Package hostdns handles ESXi host DNS validation rule reconciliation.
+package hostdns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/find"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	vapiconstants "github.com/validator-labs/validator/pkg/constants"
+	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+// dnsTimeout bounds the time spent dialing a host's DNS server
+const dnsTimeout = 5 * time.Second
+
+// Resolver performs forward and reverse DNS lookups
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+}
+
+// NewServerResolver returns a Resolver that sends all queries to the given DNS server
+func NewServerResolver(server string) Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := net.Dialer{Timeout: dnsTimeout}
+			return d.DialContext(ctx, network, net.JoinHostPort(server, "53"))
+		},
+	}
+}
+
+// ValidationService is a service that validates host DNS rules
+type ValidationService struct {
+	log         logr.Logger
+	driver      *vsphere.VCenterDriver
+	datacenter  string
+	newResolver func(server string) Resolver
+}
+
+// NewValidationService creates a new ValidationService
+func NewValidationService(log logr.Logger, driver *vsphere.VCenterDriver, datacenter string) *ValidationService {
+	return &ValidationService{
+		log:         log,
+		driver:      driver,
+		datacenter:  datacenter,
+		newResolver: NewServerResolver,
+	}
+}
+
+func buildValidationResult(rule v1alpha1.HostDNSValidationRule) *types.ValidationRuleResult {
+	state := vapi.ValidationSucceeded
+	validationType := constants.ValidationTypeHostDNS
+
+	validationRule := fmt.Sprintf("%s-%s-%s", vapiconstants.ValidationRulePrefix, validationType, rule.Name())
+
+	latestCondition := vapi.DefaultValidationCondition()
+	latestCondition.Message = "All hosts have the expected DNS configuration"
+	latestCondition.ValidationRule = util.Sanitize(validationRule)
+	latestCondition.ValidationType = validationType
+
+	return &types.ValidationRuleResult{Condition: &latestCondition, State: &state}
+}
+
+// ReconcileHostDNSRule reconciles a host DNS rule
+func (s *ValidationService) ReconcileHostDNSRule(rule v1alpha1.HostDNSValidationRule, finder *find.Finder) (*types.ValidationRuleResult, error) {
+	vr := buildValidationResult(rule)
+
+	var pattern *regexp.Regexp
+	if rule.FQDNPattern != "" {
+		var err error
+		pattern, err = regexp.Compile(rule.FQDNPattern)
+		if err != nil {
+			return vr, fmt.Errorf("invalid FQDN pattern %s: %w", rule.FQDNPattern, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	configs, notFound, err := s.driver.GetHostDNSConfigs(ctx, finder, s.datacenter, rule.ClusterName, rule.Hosts)
+	if err != nil {
+		return vr, err
+	}
+
+	failures := make([]string, 0)
+	for _, host := range notFound {
+		failures = append(failures, fmt.Sprintf("Host: %s: not found", host))
+	}
+	for _, cfg := range configs {
+		problems := validateHostDNSConfig(rule, pattern, cfg)
+		if rule.ValidateLookups {
+			problems = append(problems, s.validateLookups(ctx, cfg)...)
+		}
+		if len(problems) > 0 {
+			failures = append(failures, fmt.Sprintf("Host: %s: %s", cfg.Name, strings.Join(problems, "; ")))
+		}
+	}
+
+	if len(failures) > 0 {
+		vr.State = util.Ptr(vapi.ValidationFailed)
+		vr.Condition.Failures = failures
+		vr.Condition.Message = "One or more hosts do not have the expected DNS configuration"
+		vr.Condition.Status = corev1.ConditionFalse
+	}
+
+	return vr, nil
+}
+
+// validateHostDNSConfig compares a host's DNS servers, search domains and FQDN to the rule
+func validateHostDNSConfig(rule v1alpha1.HostDNSValidationRule, pattern *regexp.Regexp, cfg vcenter.HostDNSConfig) []string {
+	problems := make([]string, 0)
+
+	if len(rule.ExpectedDNSServers) > 0 && !sameElements(cfg.DNSServers, rule.ExpectedDNSServers) {
+		problems = append(problems, fmt.Sprintf("DNS servers %v, expected %v", cfg.DNSServers, rule.ExpectedDNSServers))
+	}
+	if len(rule.ExpectedSearchDomains) > 0 && !sameElements(cfg.SearchDomains, rule.ExpectedSearchDomains) {
+		problems = append(problems, fmt.Sprintf("search domains %v, expected %v", cfg.SearchDomains, rule.ExpectedSearchDomains))
+	}
+	if pattern != nil && !pattern.MatchString(cfg.FQDN()) {
+		problems = append(problems, fmt.Sprintf("FQDN %s does not match pattern %s", cfg.FQDN(), pattern))
+	}
+
+	return problems
+}
+
+// validateLookups ensures that a host's FQDN resolves to one of its IP addresses using its first configured DNS server,
+// and that each resolved IP address belonging to the host resolves back to its FQDN.
+func (s *ValidationService) validateLookups(ctx context.Context, cfg vcenter.HostDNSConfig) []string {
+	if len(cfg.DNSServers) == 0 {
+		return []string{"no DNS servers configured to validate lookups against"}
+	}
+	resolver := s.newResolver(cfg.DNSServers[0])
+	fqdn := cfg.FQDN()
+
+	addrs, err := resolver.LookupHost(ctx, fqdn)
+	if err != nil {
+		return []string{fmt.Sprintf("forward lookup of %s failed: %v", fqdn, err)}
+	}
+
+	problems := make([]string, 0)
+	matched := false
+	for _, addr := range addrs {
+		if !slices.Contains(cfg.IPAddresses, addr) {
+			continue
+		}
+		matched = true
+
+		names, err := resolver.LookupAddr(ctx, addr)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("reverse lookup of %s failed: %v", addr, err))
+			continue
+		}
+		if !containsName(names, fqdn) {
+			problems = append(problems, fmt.Sprintf("reverse lookup of %s returned %v, expected %s", addr, names, fqdn))
+		}
+	}
+	if !matched {
+		problems = append(problems, fmt.Sprintf("forward lookup of %s returned %v, expected one of %v", fqdn, addrs, cfg.IPAddresses))
+	}
+
+	return problems
+}
+
+func sameElements(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+func containsName(names []string, fqdn string) bool {
+	for _, n := range names {
+		if strings.EqualFold(strings.TrimSuffix(n, "."), fqdn) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package hostdns
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+type stubResolver struct {
+	hosts map[string][]string
+	addrs map[string][]string
+}
+
+func (r stubResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func (r stubResolver) LookupAddr(_ context.Context, addr string) ([]string, error) {
+	if names, ok := r.addrs[addr]; ok {
+		return names, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func TestValidateHostDNSConfig(t *testing.T) {
+	cfg := vcenter.HostDNSConfig{
+		Name:          "esx01",
+		HostName:      "esx01",
+		DomainName:    "lab.example.com",
+		DNSServers:    []string{"10.0.0.2", "10.0.0.1"},
+		SearchDomains: []string{"lab.example.com"},
+	}
+
+	tests := []struct {
+		name     string
+		rule     v1alpha1.HostDNSValidationRule
+		expected []string
+	}{
+		{
+			name: "Pass",
+			rule: v1alpha1.HostDNSValidationRule{
+				ExpectedDNSServers:    []string{"10.0.0.1", "10.0.0.2"},
+				ExpectedSearchDomains: []string{"lab.example.com"},
+				FQDNPattern:           `^esx\d+\.lab\.example\.com$`,
+			},
+			expected: []string{},
+		},
+		{
+			name: "Fail",
+			rule: v1alpha1.HostDNSValidationRule{
+				ExpectedDNSServers:    []string{"10.0.0.1"},
+				ExpectedSearchDomains: []string{"example.com"},
+				FQDNPattern:           `\.prod\.example\.com$`,
+			},
+			expected: []string{
+				"DNS servers [10.0.0.2 10.0.0.1], expected [10.0.0.1]",
+				"search domains [lab.example.com], expected [example.com]",
+				`FQDN esx01.lab.example.com does not match pattern \.prod\.example\.com$`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pattern *regexp.Regexp
+			if tt.rule.FQDNPattern != "" {
+				pattern = regexp.MustCompile(tt.rule.FQDNPattern)
+			}
+			assert.Equal(t, tt.expected, validateHostDNSConfig(tt.rule, pattern, cfg))
+		})
+	}
+}
+
+func TestValidateLookups(t *testing.T) {
+	cfg := vcenter.HostDNSConfig{
+		Name:        "esx01",
+		HostName:    "esx01",
+		DomainName:  "lab.example.com",
+		DNSServers:  []string{"10.0.0.1"},
+		IPAddresses: []string{"10.0.1.11"},
+	}
+
+	tests := []struct {
+		name     string
+		cfg      vcenter.HostDNSConfig
+		resolver stubResolver
+		expected []string
+	}{
+		{
+			name: "Consistent lookups",
+			cfg:  cfg,
+			resolver: stubResolver{
+				hosts: map[string][]string{"esx01.lab.example.com": {"10.0.1.11"}},
+				addrs: map[string][]string{"10.0.1.11": {"ESX01.lab.example.com."}},
+			},
+			expected: []string{},
+		},
+		{
+			name:     "Forward lookup fails",
+			cfg:      cfg,
+			resolver: stubResolver{},
+			expected: []string{"forward lookup of esx01.lab.example.com failed: no such host"},
+		},
+		{
+			name: "Forward lookup returns another address",
+			cfg:  cfg,
+			resolver: stubResolver{
+				hosts: map[string][]string{"esx01.lab.example.com": {"10.0.1.12"}},
+			},
+			expected: []string{"forward lookup of esx01.lab.example.com returned [10.0.1.12], expected one of [10.0.1.11]"},
+		},
+		{
+			name: "Reverse lookup returns another name",
+			cfg:  cfg,
+			resolver: stubResolver{
+				hosts: map[string][]string{"esx01.lab.example.com": {"10.0.1.11"}},
+				addrs: map[string][]string{"10.0.1.11": {"esx02.lab.example.com."}},
+			},
+			expected: []string{"reverse lookup of 10.0.1.11 returned [esx02.lab.example.com.], expected esx01.lab.example.com"},
+		},
+		{
+			name:     "No DNS servers",
+			cfg:      vcenter.HostDNSConfig{Name: "esx01", HostName: "esx01"},
+			expected: []string{"no DNS servers configured to validate lookups against"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var server string
+			s := &ValidationService{newResolver: func(srv string) Resolver {
+				server = srv
+				return tt.resolver
+			}}
+			assert.Equal(t, tt.expected, s.validateLookups(context.Background(), tt.cfg))
+			if len(tt.cfg.DNSServers) > 0 {
+				assert.Equal(t, tt.cfg.DNSServers[0], server)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+// Package hostnetwork handles ESXi host VMkernel adapter and physical NIC validation rule reconciliation.
+package hostnetwork
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/find"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	vapiconstants "github.com/validator-labs/validator/pkg/constants"
+	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+// ValidationService is a service that validates host network rules
+type ValidationService struct {
+	log        logr.Logger
+	driver     *vsphere.VCenterDriver
+	datacenter string
+}
+
+// NewValidationService creates a new ValidationService
+func NewValidationService(log logr.Logger, driver *vsphere.VCenterDriver, datacenter string) *ValidationService {
+	return &ValidationService{
+		log:        log,
+		driver:     driver,
+		datacenter: datacenter,
+	}
+}
+
+func buildValidationResult(rule v1alpha1.HostNetworkRule) *types.ValidationRuleResult {
+	state := vapi.ValidationSucceeded
+	validationType := constants.ValidationTypeHostNetwork
+
+	validationRule := fmt.Sprintf("%s-%s-%s", vapiconstants.ValidationRulePrefix, validationType, rule.Name())
+
+	latestCondition := vapi.DefaultValidationCondition()
+	latestCondition.Message = "All hosts have the required VMkernel adapters and physical NICs"
+	latestCondition.ValidationRule = util.Sanitize(validationRule)
+	latestCondition.ValidationType = validationType
+
+	return &types.ValidationRuleResult{Condition: &latestCondition, State: &state}
+}
+
+// ReconcileHostNetworkRule reconciles a host network rule
+func (s *ValidationService) ReconcileHostNetworkRule(rule v1alpha1.HostNetworkRule, finder *find.Finder) (*types.ValidationRuleResult, error) {
+	vr := buildValidationResult(rule)
+
+	cidrs := make(map[string][]*net.IPNet, len(rule.VMKernelAdapters))
+	for _, req := range rule.VMKernelAdapters {
+		for _, c := range req.CIDRs {
+			_, ipNet, err := net.ParseCIDR(c)
+			if err != nil {
+				return vr, fmt.Errorf("invalid CIDR %s for service %s: %w", c, req.Service, err)
+			}
+			cidrs[req.Service] = append(cidrs[req.Service], ipNet)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	configs, notFound, err := s.driver.GetHostNetworkConfigs(ctx, finder, s.datacenter, rule.ClusterName, rule.Hosts)
+	if err != nil {
+		return vr, err
+	}
+
+	failures := make([]string, 0)
+	for _, host := range notFound {
+		failures = append(failures, fmt.Sprintf("Host: %s: not found", host))
+	}
+	for _, cfg := range configs {
+		problems := validateVMKernelAdapters(rule.VMKernelAdapters, cidrs, cfg)
+		problems = append(problems, validatePhysicalNICs(rule.PhysicalNICs, cfg)...)
+		if len(problems) > 0 {
+			failures = append(failures, fmt.Sprintf("Host: %s: %s", cfg.Name, strings.Join(problems, "; ")))
+		}
+	}
+	failures = append(failures, validateMTUConsistency(rule.VMKernelAdapters, configs)...)
+
+	if len(failures) > 0 {
+		vr.State = util.Ptr(vapi.ValidationFailed)
+		vr.Condition.Failures = failures
+		vr.Condition.Message = "One or more hosts do not have the required VMkernel adapters and physical NICs"
+		vr.Condition.Status = corev1.ConditionFalse
+	}
+
+	return vr, nil
+}
+
+// validateVMKernelAdapters ensures that each required service is enabled on a VMkernel adapter with a sufficient MTU
+// and an IP address within the allowed CIDRs
+func validateVMKernelAdapters(reqs []v1alpha1.VMKernelAdapterRequirement, cidrs map[string][]*net.IPNet, cfg vcenter.HostNetworkConfig) []string {
+	problems := make([]string, 0)
+
+	for _, req := range reqs {
+		adapters := adaptersForService(cfg, req.Service)
+		if len(adapters) == 0 {
+			problems = append(problems, fmt.Sprintf("no VMkernel adapter has %s enabled", req.Service))
+			continue
+		}
+		for _, a := range adapters {
+			if req.MinMTU > 0 && a.MTU < req.MinMTU {
+				problems = append(problems, fmt.Sprintf("%s (%s) MTU %d is less than %d", a.Device, req.Service, a.MTU, req.MinMTU))
+			}
+			if len(cidrs[req.Service]) > 0 && !inAnyCIDR(a.IPAddress, cidrs[req.Service]) {
+				problems = append(problems, fmt.Sprintf("%s (%s) IP address %q is not within %v", a.Device, req.Service, a.IPAddress, req.CIDRs))
+			}
+		}
+	}
+
+	return problems
+}
+
+// validatePhysicalNICs ensures that each required physical NIC exists and its link is up at a sufficient speed
+func validatePhysicalNICs(reqs []v1alpha1.PhysicalNICRequirement, cfg vcenter.HostNetworkConfig) []string {
+	problems := make([]string, 0)
+
+	for _, req := range reqs {
+		idx := slices.IndexFunc(cfg.PhysicalNICs, func(n vcenter.PhysicalNIC) bool { return n.Device == req.Device })
+		if idx == -1 {
+			problems = append(problems, fmt.Sprintf("physical NIC %s not found", req.Device))
+			continue
+		}
+		nic := cfg.PhysicalNICs[idx]
+		if nic.SpeedMb == 0 {
+			problems = append(problems, fmt.Sprintf("physical NIC %s link is down", req.Device))
+			continue
+		}
+		if nic.SpeedMb < req.MinSpeedMb {
+			problems = append(problems, fmt.Sprintf("physical NIC %s link speed %d Mb is less than %d Mb", req.Device, nic.SpeedMb, req.MinSpeedMb))
+		}
+	}
+
+	return problems
+}
+
+// validateMTUConsistency ensures that the VMkernel adapters enabled for each required service have the same MTU on every host
+func validateMTUConsistency(reqs []v1alpha1.VMKernelAdapterRequirement, configs []vcenter.HostNetworkConfig) []string {
+	failures := make([]string, 0)
+
+	for _, req := range reqs {
+		hostsByMTU := make(map[int][]string)
+		for _, cfg := range configs {
+			for _, a := range adaptersForService(cfg, req.Service) {
+				if !slices.Contains(hostsByMTU[a.MTU], cfg.Name) {
+					hostsByMTU[a.MTU] = append(hostsByMTU[a.MTU], cfg.Name)
+				}
+			}
+		}
+		if len(hostsByMTU) < 2 {
+			continue
+		}
+
+		mtus := make([]int, 0, len(hostsByMTU))
+		for mtu := range hostsByMTU {
+			mtus = append(mtus, mtu)
+		}
+		sort.Ints(mtus)
+		details := make([]string, 0, len(mtus))
+		for _, mtu := range mtus {
+			details = append(details, fmt.Sprintf("%d on %v", mtu, hostsByMTU[mtu]))
+		}
+		failures = append(failures, fmt.Sprintf("VMkernel adapters for %s have inconsistent MTUs across hosts: %s", req.Service, strings.Join(details, ", ")))
+	}
+
+	return failures
+}
+
+func adaptersForService(cfg vcenter.HostNetworkConfig, service string) []vcenter.VMKernelAdapter {
+	adapters := make([]vcenter.VMKernelAdapter, 0)
+	for _, a := range cfg.VMKernelAdapters {
+		if slices.Contains(a.Services, service) {
+			adapters = append(adapters, a)
+		}
+	}
+	return adapters
+}
+
+func inAnyCIDR(addr string, cidrs []*net.IPNet) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, c := range cidrs {
+		if c.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
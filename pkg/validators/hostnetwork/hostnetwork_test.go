@@ -0,0 +1,148 @@
+package hostnetwork
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+func mustParseCIDR(t *testing.T, c string) *net.IPNet {
+	_, ipNet, err := net.ParseCIDR(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ipNet
+}
+
+func TestValidateVMKernelAdapters(t *testing.T) {
+	cfg := vcenter.HostNetworkConfig{
+		Name: "esx01",
+		VMKernelAdapters: []vcenter.VMKernelAdapter{
+			{Device: "vmk0", Services: []string{"management"}, MTU: 1500, IPAddress: "10.0.0.11"},
+			{Device: "vmk1", Services: []string{"vmotion"}, MTU: 9000, IPAddress: "10.1.0.11"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		reqs     []v1alpha1.VMKernelAdapterRequirement
+		expected []string
+	}{
+		{
+			name: "Pass",
+			reqs: []v1alpha1.VMKernelAdapterRequirement{
+				{Service: "management", CIDRs: []string{"10.0.0.0/24"}},
+				{Service: "vmotion", MinMTU: 9000, CIDRs: []string{"10.1.0.0/24"}},
+			},
+			expected: []string{},
+		},
+		{
+			name: "Fail",
+			reqs: []v1alpha1.VMKernelAdapterRequirement{
+				{Service: "management", MinMTU: 9000, CIDRs: []string{"10.2.0.0/24"}},
+				{Service: "vsan"},
+			},
+			expected: []string{
+				"vmk0 (management) MTU 1500 is less than 9000",
+				`vmk0 (management) IP address "10.0.0.11" is not within [10.2.0.0/24]`,
+				"no VMkernel adapter has vsan enabled",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cidrs := make(map[string][]*net.IPNet)
+			for _, req := range tt.reqs {
+				for _, c := range req.CIDRs {
+					cidrs[req.Service] = append(cidrs[req.Service], mustParseCIDR(t, c))
+				}
+			}
+			assert.Equal(t, tt.expected, validateVMKernelAdapters(tt.reqs, cidrs, cfg))
+		})
+	}
+}
+
+func TestValidatePhysicalNICs(t *testing.T) {
+	cfg := vcenter.HostNetworkConfig{
+		Name: "esx01",
+		PhysicalNICs: []vcenter.PhysicalNIC{
+			{Device: "vmnic0", SpeedMb: 10000},
+			{Device: "vmnic1", SpeedMb: 1000},
+			{Device: "vmnic2", SpeedMb: 0},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		reqs     []v1alpha1.PhysicalNICRequirement
+		expected []string
+	}{
+		{
+			name:     "Pass",
+			reqs:     []v1alpha1.PhysicalNICRequirement{{Device: "vmnic0", MinSpeedMb: 10000}, {Device: "vmnic1"}},
+			expected: []string{},
+		},
+		{
+			name: "Fail",
+			reqs: []v1alpha1.PhysicalNICRequirement{
+				{Device: "vmnic1", MinSpeedMb: 10000},
+				{Device: "vmnic2"},
+				{Device: "vmnic3"},
+			},
+			expected: []string{
+				"physical NIC vmnic1 link speed 1000 Mb is less than 10000 Mb",
+				"physical NIC vmnic2 link is down",
+				"physical NIC vmnic3 not found",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validatePhysicalNICs(tt.reqs, cfg))
+		})
+	}
+}
+
+func TestValidateMTUConsistency(t *testing.T) {
+	host := func(name string, mtu int) vcenter.HostNetworkConfig {
+		return vcenter.HostNetworkConfig{
+			Name: name,
+			VMKernelAdapters: []vcenter.VMKernelAdapter{
+				{Device: "vmk0", Services: []string{"management"}, MTU: 1500},
+				{Device: "vmk1", Services: []string{"vmotion"}, MTU: mtu},
+			},
+		}
+	}
+	reqs := []v1alpha1.VMKernelAdapterRequirement{{Service: "management"}, {Service: "vmotion"}}
+
+	tests := []struct {
+		name     string
+		configs  []vcenter.HostNetworkConfig
+		expected []string
+	}{
+		{
+			name:     "Pass",
+			configs:  []vcenter.HostNetworkConfig{host("esx01", 9000), host("esx02", 9000)},
+			expected: []string{},
+		},
+		{
+			name:    "Fail",
+			configs: []vcenter.HostNetworkConfig{host("esx01", 9000), host("esx02", 1500), host("esx03", 9000)},
+			expected: []string{
+				"VMkernel adapters for vmotion have inconsistent MTUs across hosts: 1500 on [esx02], 9000 on [esx01 esx03]",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validateMTUConsistency(reqs, tt.configs))
+		})
+	}
+}
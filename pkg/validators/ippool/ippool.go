@@ -0,0 +1,259 @@
+// Package ippool handles static IP pool validation rule reconciliation.
+package ippool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/find"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	vapiconstants "github.com/validator-labs/validator/pkg/constants"
+	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+// ValidationService is a service that validates IP pool rules
+type ValidationService struct {
+	log        logr.Logger
+	driver     *vsphere.VCenterDriver
+	datacenter string
+}
+
+// NewValidationService creates a new ValidationService
+func NewValidationService(log logr.Logger, driver *vsphere.VCenterDriver, datacenter string) *ValidationService {
+	return &ValidationService{
+		log:        log,
+		driver:     driver,
+		datacenter: datacenter,
+	}
+}
+
+func buildValidationResult(rule v1alpha1.IPPoolValidationRule) *types.ValidationRuleResult {
+	state := vapi.ValidationSucceeded
+	validationType := constants.ValidationTypeIPPool
+
+	validationRule := fmt.Sprintf("%s-%s-%s", vapiconstants.ValidationRulePrefix, validationType, rule.Name())
+
+	latestCondition := vapi.DefaultValidationCondition()
+	latestCondition.Message = "IP pool is valid and has sufficient free addresses"
+	latestCondition.ValidationRule = util.Sanitize(validationRule)
+	latestCondition.ValidationType = validationType
+
+	return &types.ValidationRuleResult{Condition: &latestCondition, State: &state}
+}
+
+// ReconcileIPPoolRule reconciles an IP pool rule
+func (s *ValidationService) ReconcileIPPoolRule(rule v1alpha1.IPPoolValidationRule, finder *find.Finder) (*types.ValidationRuleResult, error) {
+	vr := buildValidationResult(rule)
+
+	pool, err := parseIPPool(rule)
+	if err != nil {
+		return vr, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	failures := make([]string, 0)
+
+	if _, err := finder.Network(ctx, rule.PortGroup); err != nil {
+		var notFoundErr *find.NotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return vr, err
+		}
+		failures = append(failures, fmt.Sprintf("port group %s not found", rule.PortGroup))
+	}
+
+	vms, err := s.driver.GetVMIPAddresses(ctx, s.datacenter)
+	if err != nil {
+		return vr, err
+	}
+	failures = append(failures, validateIPPool(pool, vms, rule.MinFreeIPs)...)
+
+	if len(failures) > 0 {
+		vr.State = util.Ptr(vapi.ValidationFailed)
+		vr.Condition.Failures = failures
+		vr.Condition.Message = "IP pool is invalid or does not have sufficient free addresses"
+		vr.Condition.Status = corev1.ConditionFalse
+	}
+
+	return vr, nil
+}
+
+// ipPool is a parsed IP pool
+type ipPool struct {
+	cidrs   []*net.IPNet
+	subnet  *net.IPNet
+	gateway net.IP
+}
+
+// parseIPPool parses and sanity checks an IP pool rule's CIDR ranges, subnet and gateway
+func parseIPPool(rule v1alpha1.IPPoolValidationRule) (*ipPool, error) {
+	if len(rule.CIDRs) == 0 {
+		return nil, fmt.Errorf("IP pool must contain at least one CIDR")
+	}
+
+	pool := &ipPool{}
+	for _, c := range rule.CIDRs {
+		_, ipNet, err := net.ParseCIDR(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %s: %w", c, err)
+		}
+		for _, other := range pool.cidrs {
+			if other.Contains(ipNet.IP) || ipNet.Contains(other.IP) {
+				return nil, fmt.Errorf("CIDR %s overlaps with CIDR %s", ipNet, other)
+			}
+		}
+		pool.cidrs = append(pool.cidrs, ipNet)
+	}
+
+	if rule.Subnet != "" {
+		_, subnet, err := net.ParseCIDR(rule.Subnet)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subnet %s: %w", rule.Subnet, err)
+		}
+		pool.subnet = subnet
+	}
+
+	pool.gateway = net.ParseIP(rule.Gateway)
+	if pool.gateway == nil {
+		return nil, fmt.Errorf("invalid gateway %s", rule.Gateway)
+	}
+
+	return pool, nil
+}
+
+// contains returns whether an IP address is within any of the IP pool's CIDR ranges
+func (p *ipPool) contains(ip net.IP) bool {
+	for _, c := range p.cidrs {
+		if c.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// size returns the total number of addresses in the IP pool, saturating at math.MaxInt
+func (p *ipPool) size() int {
+	total := 0
+	for _, c := range p.cidrs {
+		ones, bits := c.Mask.Size()
+		if bits-ones >= 62 {
+			return math.MaxInt
+		}
+		n := 1 << (bits - ones)
+		if total > math.MaxInt-n {
+			return math.MaxInt
+		}
+		total += n
+	}
+	return total
+}
+
+// subnets returns the subnets that the IP pool's addresses belong to
+func (p *ipPool) subnets() []*net.IPNet {
+	if p.subnet != nil {
+		return []*net.IPNet{p.subnet}
+	}
+	return p.cidrs
+}
+
+// reserved returns the addresses that can never be allocated from the IP pool:
+// the gateway and, for IPv4 subnets, the network and broadcast addresses.
+func (p *ipPool) reserved() []net.IP {
+	reserved := []net.IP{p.gateway}
+	for _, s := range p.subnets() {
+		ip := s.IP.To4()
+		if ip == nil {
+			continue
+		}
+		ones, bits := s.Mask.Size()
+		if bits-ones < 2 {
+			continue
+		}
+		broadcast := make(net.IP, len(ip))
+		for i := range ip {
+			broadcast[i] = ip[i] | ^s.Mask[i]
+		}
+		reserved = append(reserved, ip, broadcast)
+	}
+	return reserved
+}
+
+// validateIPPool ensures that the gateway is within the subnet, that no VM uses an address in the IP pool,
+// and that the IP pool has at least minFree free addresses
+func validateIPPool(p *ipPool, vms []vcenter.VM, minFree int) []string {
+	failures := make([]string, 0)
+
+	if p.subnet != nil {
+		if !p.subnet.Contains(p.gateway) {
+			failures = append(failures, fmt.Sprintf("gateway %s is not within subnet %s", p.gateway, p.subnet))
+		}
+		for _, c := range p.cidrs {
+			subnetOnes, _ := p.subnet.Mask.Size()
+			ones, _ := c.Mask.Size()
+			if !p.subnet.Contains(c.IP) || ones < subnetOnes {
+				failures = append(failures, fmt.Sprintf("CIDR %s is not within subnet %s", c, p.subnet))
+			}
+		}
+	} else if !p.contains(p.gateway) {
+		failures = append(failures, fmt.Sprintf("gateway %s is not within any of %v", p.gateway, p.cidrs))
+	}
+
+	unavailable := make(map[string]bool)
+	for _, ip := range p.reserved() {
+		if p.contains(ip) {
+			unavailable[ip.String()] = true
+		}
+	}
+	for _, vm := range vms {
+		for _, addr := range vmIPAddresses(vm) {
+			ip := net.ParseIP(addr)
+			if ip == nil || !p.contains(ip) {
+				continue
+			}
+			failures = append(failures, fmt.Sprintf("VM: %s: IP address %s is within the IP pool", vm.Name, addr))
+			unavailable[ip.String()] = true
+		}
+	}
+
+	free := p.size() - len(unavailable)
+	if free < minFree {
+		failures = append(failures, fmt.Sprintf("IP pool has %d free addresses, expected at least %d", free, minFree))
+	}
+
+	return failures
+}
+
+// vmIPAddresses returns the unique guest IP addresses of a VM
+func vmIPAddresses(vm vcenter.VM) []string {
+	addrs := make([]string, 0, len(vm.Network)+1)
+	seen := make(map[string]bool)
+	for _, addr := range append([]string{vm.IPAddress}, networkIPs(vm.Network)...) {
+		if addr == "" || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+func networkIPs(networks []vcenter.Network) []string {
+	ips := make([]string, 0, len(networks))
+	for _, n := range networks {
+		ips = append(ips, n.IP)
+	}
+	return ips
+}
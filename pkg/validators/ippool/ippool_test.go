@@ -0,0 +1,102 @@
+package ippool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+func TestParseIPPool(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        v1alpha1.IPPoolValidationRule
+		expectedErr string
+	}{
+		{
+			name: "Pass",
+			rule: v1alpha1.IPPoolValidationRule{CIDRs: []string{"10.0.0.0/28", "10.0.0.32/28"}, Subnet: "10.0.0.0/24", Gateway: "10.0.0.1"},
+		},
+		{
+			name:        "Overlapping CIDRs",
+			rule:        v1alpha1.IPPoolValidationRule{CIDRs: []string{"10.0.0.0/24", "10.0.0.16/28"}, Gateway: "10.0.0.1"},
+			expectedErr: "CIDR 10.0.0.16/28 overlaps with CIDR 10.0.0.0/24",
+		},
+		{
+			name:        "Invalid CIDR",
+			rule:        v1alpha1.IPPoolValidationRule{CIDRs: []string{"10.0.0.0"}, Gateway: "10.0.0.1"},
+			expectedErr: "invalid CIDR 10.0.0.0: invalid CIDR address: 10.0.0.0",
+		},
+		{
+			name:        "Invalid gateway",
+			rule:        v1alpha1.IPPoolValidationRule{CIDRs: []string{"10.0.0.0/24"}, Gateway: "gateway"},
+			expectedErr: "invalid gateway gateway",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseIPPool(tt.rule)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateIPPool(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     v1alpha1.IPPoolValidationRule
+		vms      []vcenter.VM
+		expected []string
+	}{
+		{
+			name: "Pass",
+			rule: v1alpha1.IPPoolValidationRule{CIDRs: []string{"10.0.0.0/29"}, Gateway: "10.0.0.1", MinFreeIPs: 4},
+			vms: []vcenter.VM{
+				{Name: "vm1", IPAddress: "10.0.1.10", Network: []vcenter.Network{{IP: "10.0.1.10"}, {IP: "fe80::1"}}},
+			},
+			expected: []string{},
+		},
+		{
+			name:     "Pass with pool smaller than subnet",
+			rule:     v1alpha1.IPPoolValidationRule{CIDRs: []string{"10.0.0.64/30"}, Subnet: "10.0.0.0/24", Gateway: "10.0.0.1", MinFreeIPs: 4},
+			vms:      []vcenter.VM{{Name: "vm1", IPAddress: "10.0.0.10"}},
+			expected: []string{},
+		},
+		{
+			name: "Fail",
+			rule: v1alpha1.IPPoolValidationRule{CIDRs: []string{"10.0.0.0/29", "10.0.2.0/30"}, Subnet: "10.0.0.0/24", Gateway: "10.0.1.1", MinFreeIPs: 10},
+			vms: []vcenter.VM{
+				{Name: "vm1", IPAddress: "10.0.0.2", Network: []vcenter.Network{{IP: "10.0.0.2"}, {IP: "10.0.0.3"}}},
+			},
+			expected: []string{
+				"gateway 10.0.1.1 is not within subnet 10.0.0.0/24",
+				"CIDR 10.0.2.0/30 is not within subnet 10.0.0.0/24",
+				"VM: vm1: IP address 10.0.0.2 is within the IP pool",
+				"VM: vm1: IP address 10.0.0.3 is within the IP pool",
+				"IP pool has 9 free addresses, expected at least 10",
+			},
+		},
+		{
+			name: "Fail insufficient free addresses",
+			rule: v1alpha1.IPPoolValidationRule{CIDRs: []string{"10.0.0.0/29"}, Gateway: "10.0.0.1", MinFreeIPs: 6},
+			expected: []string{
+				"IP pool has 5 free addresses, expected at least 6",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, err := parseIPPool(tt.rule)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, validateIPPool(pool, tt.vms, tt.rule.MinFreeIPs))
+		})
+	}
+}
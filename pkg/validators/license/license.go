@@ -0,0 +1,156 @@
+// Package license handles ESXi host license validation rule reconciliation.
+package license
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/find"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	vapiconstants "github.com/validator-labs/validator/pkg/constants"
+	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+const day = 24 * time.Hour
+
+// ValidationService is a service that validates license rules
+type ValidationService struct {
+	log        logr.Logger
+	driver     *vsphere.VCenterDriver
+	datacenter string
+	now        func() time.Time
+}
+
+// NewValidationService creates a new ValidationService
+func NewValidationService(log logr.Logger, driver *vsphere.VCenterDriver, datacenter string) *ValidationService {
+	return &ValidationService{
+		log:        log,
+		driver:     driver,
+		datacenter: datacenter,
+		now:        time.Now,
+	}
+}
+
+func buildValidationResult(rule v1alpha1.LicenseValidationRule) *types.ValidationRuleResult {
+	state := vapi.ValidationSucceeded
+	validationType := constants.ValidationTypeLicense
+
+	validationRule := fmt.Sprintf("%s-%s-%s", vapiconstants.ValidationRulePrefix, validationType, rule.Name())
+
+	latestCondition := vapi.DefaultValidationCondition()
+	latestCondition.Message = "All hosts have valid licenses with the required features"
+	latestCondition.ValidationRule = util.Sanitize(validationRule)
+	latestCondition.ValidationType = validationType
+
+	return &types.ValidationRuleResult{Condition: &latestCondition, State: &state}
+}
+
+// ReconcileLicenseRule reconciles a license rule
+func (s *ValidationService) ReconcileLicenseRule(rule v1alpha1.LicenseValidationRule, finder *find.Finder) (*types.ValidationRuleResult, error) {
+	vr := buildValidationResult(rule)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hostLicenses, notFound, unlicensed, err := s.driver.GetHostLicenses(ctx, finder, s.datacenter, rule.ClusterName, rule.Hosts)
+	if err != nil {
+		return vr, err
+	}
+
+	failures := make([]string, 0)
+	for _, host := range notFound {
+		failures = append(failures, fmt.Sprintf("Host: %s: not found", host))
+	}
+	for _, host := range unlicensed {
+		failures = append(failures, fmt.Sprintf("Host: %s: no license assigned", host))
+	}
+	for _, hl := range hostLicenses {
+		if problems := validateLicense(rule, hl.License, s.now()); len(problems) > 0 {
+			failures = append(failures, fmt.Sprintf("Host: %s: license %s: %s", hl.Host, hl.License.Name, strings.Join(problems, "; ")))
+		}
+	}
+
+	if len(failures) > 0 {
+		vr.State = util.Ptr(vapi.ValidationFailed)
+		vr.Condition.Failures = failures
+		vr.Condition.Message = "One or more hosts do not have valid licenses with the required features"
+		vr.Condition.Status = corev1.ConditionFalse
+	}
+
+	return vr, nil
+}
+
+// validateLicense compares a license's features, expiration date and free capacity to the rule
+func validateLicense(rule v1alpha1.LicenseValidationRule, l vcenter.License, now time.Time) []string {
+	problems := make([]string, 0)
+
+	for _, feature := range rule.RequiredFeatures {
+		if !hasFeature(l.Features, feature) {
+			problems = append(problems, fmt.Sprintf("feature %s is not licensed", feature))
+		}
+	}
+
+	if l.ExpirationDate != nil {
+		kind := "license"
+		if l.Evaluation() {
+			kind = "evaluation license"
+		}
+		expiry := l.ExpirationDate.UTC().Format(time.RFC3339)
+		deadline := now.Add(time.Duration(rule.MinValidDays) * day)
+		switch {
+		case !now.Before(*l.ExpirationDate):
+			problems = append(problems, fmt.Sprintf("%s expired at %s", kind, expiry))
+		case deadline.After(*l.ExpirationDate):
+			days := int(l.ExpirationDate.Sub(now) / day)
+			problems = append(problems, fmt.Sprintf("%s expires at %s, in %d day(s)", kind, expiry, days))
+		}
+	}
+
+	// a total of zero denotes unlimited capacity
+	if rule.MinFreeCapacity > 0 && l.Total > 0 {
+		if free := int(l.Total - l.Used); free < rule.MinFreeCapacity {
+			problems = append(problems, fmt.Sprintf(
+				"%d of %d %s unit(s) free, expected at least %d", free, l.Total, l.CostUnit, rule.MinFreeCapacity,
+			))
+		}
+	}
+
+	return problems
+}
+
+// hasFeature returns whether a license's features include the required feature.
+// Features may be versioned, e.g., "serialuri:2", in which case the licensed version must be at least the required version.
+func hasFeature(features []string, required string) bool {
+	name, version := parseFeature(required)
+	for _, f := range features {
+		n, v := parseFeature(f)
+		if n == name && v >= version {
+			return true
+		}
+	}
+	return false
+}
+
+func parseFeature(feature string) (string, int) {
+	name, version, ok := strings.Cut(feature, ":")
+	if !ok {
+		return feature, 0
+	}
+	v, err := strconv.Atoi(version)
+	if err != nil {
+		return feature, 0
+	}
+	return name, v
+}
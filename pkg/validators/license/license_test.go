@@ -0,0 +1,88 @@
+package license
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+func TestValidateLicense(t *testing.T) {
+	now := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	expiry := time.Date(2024, time.June, 8, 0, 0, 0, 0, time.UTC)
+
+	enterprise := vcenter.License{
+		Name:     "vSphere 8 Enterprise Plus",
+		CostUnit: "cpuPackage",
+		Total:    16,
+		Used:     12,
+		Features: []string{"drs", "dvs", "vsan", "serialuri:2"},
+	}
+	evaluation := vcenter.License{
+		Name:           "Evaluation Mode",
+		EditionKey:     vcenter.LicenseEditionEvaluation,
+		Features:       []string{"drs"},
+		ExpirationDate: &expiry,
+	}
+
+	tests := []struct {
+		name     string
+		rule     v1alpha1.LicenseValidationRule
+		license  vcenter.License
+		now      time.Time
+		expected []string
+	}{
+		{
+			name: "Pass with features and capacity",
+			rule: v1alpha1.LicenseValidationRule{
+				RequiredFeatures: []string{"drs", "vsan", "serialuri:1"},
+				MinValidDays:     30,
+				MinFreeCapacity:  4,
+			},
+			license:  enterprise,
+			now:      now,
+			expected: []string{},
+		},
+		{
+			name: "Fail with missing features and insufficient capacity",
+			rule: v1alpha1.LicenseValidationRule{
+				RequiredFeatures: []string{"wcp", "serialuri:3"},
+				MinFreeCapacity:  5,
+			},
+			license: enterprise,
+			now:     now,
+			expected: []string{
+				"feature wcp is not licensed",
+				"feature serialuri:3 is not licensed",
+				"4 of 16 cpuPackage unit(s) free, expected at least 5",
+			},
+		},
+		{
+			name:    "Fail with evaluation license expiring within window",
+			rule:    v1alpha1.LicenseValidationRule{MinValidDays: 30, MinFreeCapacity: 1},
+			license: evaluation,
+			now:     now,
+			expected: []string{
+				"evaluation license expires at 2024-06-08T00:00:00Z, in 7 day(s)",
+			},
+		},
+		{
+			name:    "Fail with expired evaluation license",
+			rule:    v1alpha1.LicenseValidationRule{},
+			license: evaluation,
+			now:     expiry,
+			expected: []string{
+				"evaluation license expired at 2024-06-08T00:00:00Z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validateLicense(tt.rule, tt.license, tt.now))
+		})
+	}
+}
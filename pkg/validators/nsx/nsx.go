@@ -0,0 +1,113 @@
+// Package nsx handles NSX segment validation rule reconciliation.
+package nsx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/find"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	vapiconstants "github.com/validator-labs/validator/pkg/constants"
+	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+// ValidationService is a service that validates NSX segment rules
+type ValidationService struct {
+	log        logr.Logger
+	driver     *vsphere.VCenterDriver
+	datacenter string
+}
+
+// NewValidationService creates a new ValidationService
+func NewValidationService(log logr.Logger, driver *vsphere.VCenterDriver, datacenter string) *ValidationService {
+	return &ValidationService{
+		log:        log,
+		driver:     driver,
+		datacenter: datacenter,
+	}
+}
+
+func buildValidationResult(rule v1alpha1.NSXSegmentValidationRule) *types.ValidationRuleResult {
+	state := vapi.ValidationSucceeded
+	validationType := constants.ValidationTypeNSXSegment
+
+	validationRule := fmt.Sprintf("%s-%s-%s", vapiconstants.ValidationRulePrefix, validationType, rule.Name())
+
+	latestCondition := vapi.DefaultValidationCondition()
+	latestCondition.Message = fmt.Sprintf("NSX segment %s is attached to all hosts in cluster %s", rule.SegmentName, rule.ClusterName)
+	latestCondition.ValidationRule = util.Sanitize(validationRule)
+	latestCondition.ValidationType = validationType
+
+	return &types.ValidationRuleResult{Condition: &latestCondition, State: &state}
+}
+
+// ReconcileNSXSegmentRule reconciles an NSX segment rule
+func (s *ValidationService) ReconcileNSXSegmentRule(rule v1alpha1.NSXSegmentValidationRule, finder *find.Finder) (*types.ValidationRuleResult, error) {
+	vr := buildValidationResult(rule)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	failures := make([]string, 0)
+
+	segment, err := s.driver.GetNSXSegment(ctx, finder, s.datacenter, rule.SegmentName)
+	if err != nil {
+		var notFoundErr *find.NotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return vr, err
+		}
+		failures = append(failures, fmt.Sprintf("NSX segment %s not found in datacenter %s", rule.SegmentName, s.datacenter))
+	} else {
+		hosts, err := s.driver.GetHostSystems(ctx, s.datacenter, rule.ClusterName)
+		if err != nil {
+			return vr, err
+		}
+		failures = append(failures, validateSegment(rule, segment, hosts)...)
+	}
+
+	if len(failures) > 0 {
+		vr.State = util.Ptr(vapi.ValidationFailed)
+		vr.Condition.Failures = failures
+		vr.Condition.Message = fmt.Sprintf("NSX segment %s is invalid or not attached to all hosts in cluster %s", rule.SegmentName, rule.ClusterName)
+		vr.Condition.Status = corev1.ConditionFalse
+	}
+
+	return vr, nil
+}
+
+// validateSegment ensures that the network is an NSX segment with the expected logical switch ID
+// that is attached to every host in the cluster
+func validateSegment(rule v1alpha1.NSXSegmentValidationRule, segment *vcenter.NSXSegment, hosts []vcenter.HostSystem) []string {
+	if !segment.NSX {
+		return []string{fmt.Sprintf("network %s is a %s, not an NSX segment", segment.Name, segment.Type)}
+	}
+
+	failures := make([]string, 0)
+	if rule.LogicalSwitchID != "" && segment.LogicalSwitchID != rule.LogicalSwitchID {
+		failures = append(failures, fmt.Sprintf(
+			"NSX segment %s has logical switch ID %s, expected %s", segment.Name, segment.LogicalSwitchID, rule.LogicalSwitchID,
+		))
+	}
+
+	attached := make(map[string]bool, len(segment.Hosts))
+	for _, h := range segment.Hosts {
+		attached[h] = true
+	}
+	for _, h := range hosts {
+		if !attached[h.Name] {
+			failures = append(failures, fmt.Sprintf("Host: %s: not attached to NSX segment %s", h.Name, segment.Name))
+		}
+	}
+
+	return failures
+}
@@ -0,0 +1,64 @@
+package nsx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+func TestValidateSegment(t *testing.T) {
+	hosts := []vcenter.HostSystem{{Name: "esx01"}, {Name: "esx02"}, {Name: "esx03"}}
+	rule := v1alpha1.NSXSegmentValidationRule{
+		SegmentName:     "tkg-segment",
+		LogicalSwitchID: "0b7a9c3e-5f6d-4f1b-9c1a-2d3e4f5a6b7c",
+	}
+
+	tests := []struct {
+		name     string
+		segment  *vcenter.NSXSegment
+		expected []string
+	}{
+		{
+			name: "Pass with opaque network",
+			segment: &vcenter.NSXSegment{
+				Name:            "tkg-segment",
+				Type:            "Opaque Network",
+				NSX:             true,
+				LogicalSwitchID: "0b7a9c3e-5f6d-4f1b-9c1a-2d3e4f5a6b7c",
+				Hosts:           []string{"esx01", "esx02", "esx03", "esx04"},
+			},
+			expected: []string{},
+		},
+		{
+			name: "Fail with non-NSX distributed port group",
+			segment: &vcenter.NSXSegment{
+				Name: "tkg-segment",
+				Type: "Distributed Port Group",
+			},
+			expected: []string{"network tkg-segment is a Distributed Port Group, not an NSX segment"},
+		},
+		{
+			name: "Fail with unexpected logical switch and detached host",
+			segment: &vcenter.NSXSegment{
+				Name:            "tkg-segment",
+				Type:            "Distributed Port Group",
+				NSX:             true,
+				LogicalSwitchID: "1c8b0d4f-6a7e-4a2c-8d2b-3e4f5a6b7c8d",
+				Hosts:           []string{"esx01", "esx03"},
+			},
+			expected: []string{
+				"NSX segment tkg-segment has logical switch ID 1c8b0d4f-6a7e-4a2c-8d2b-3e4f5a6b7c8d, expected 0b7a9c3e-5f6d-4f1b-9c1a-2d3e4f5a6b7c",
+				"Host: esx02: not attached to NSX segment tkg-segment",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validateSegment(rule, tt.segment, hosts))
+		})
+	}
+}
@@ -4,6 +4,7 @@ package ntp
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/vmware/govmomi/find"
@@ -50,13 +51,22 @@ func buildValidationResult(rule v1alpha1.NTPValidationRule) *types.ValidationRul
 
 // ReconcileNTPRule reconciles the NTP rule
 func (n *ValidationService) ReconcileNTPRule(rule v1alpha1.NTPValidationRule, finder *find.Finder) (*types.ValidationRuleResult, error) {
-	var err error
 	vr := buildValidationResult(rule)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	valid, failures, err := n.driver.ValidateHostNTPSettings(ctx, finder, n.datacenter, rule.ClusterName, rule.Hosts)
+	opts := vsphere.NTPOptions{
+		Protocol:        rule.Protocol,
+		ExpectedServers: rule.ExpectedServers,
+		ServerMatch:     rule.ServerMatch,
+		MaxClockSkew:    time.Duration(rule.MaxClockSkewMilliseconds) * time.Millisecond,
+	}
+
+	valid, failures, err := n.driver.ValidateHostNTPSettings(ctx, finder, n.datacenter, rule.ClusterName, rule.Hosts, opts)
+	if err != nil {
+		return vr, err
+	}
 	if !valid {
 		vr.Condition.Failures = failures
 	}
@@ -65,8 +75,7 @@ func (n *ValidationService) ReconcileNTPRule(rule v1alpha1.NTPValidationRule, fi
 		vr.State = util.Ptr(vapi.ValidationFailed)
 		vr.Condition.Message = fmt.Sprintf("One or more NTP rules were not satisfied for rule: %s", rule.Name())
 		vr.Condition.Status = corev1.ConditionFalse
-		err = fmt.Errorf("one or more NTP rules were not satisfied for rule: %s", rule.Name())
 	}
 
-	return vr, err
+	return vr, nil
 }
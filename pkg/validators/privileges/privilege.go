@@ -50,6 +50,17 @@ func (s *PrivilegeValidationService) ReconcilePrivilegeRule(rule v1alpha1.Privil
 
 	vr.Condition.Failures, err = s.driver.ValidateUserPrivilegeOnEntities(ctx, s.authManager, s.datacenter, s.username, finder, rule)
 
+	if len(vr.Condition.Failures) > 0 && err == nil && rule.Remediation.Enabled {
+		record, rerr := s.remediate(ctx, rule, finder)
+		vr.Condition.Details = append(vr.Condition.Details, record...)
+		switch {
+		case rerr != nil:
+			vr.Condition.Failures = append(vr.Condition.Failures, fmt.Sprintf("remediation failed: %s", rerr))
+		case !rule.Remediation.DryRun:
+			vr.Condition.Failures, err = s.driver.ValidateUserPrivilegeOnEntities(ctx, s.authManager, s.datacenter, s.username, finder, rule)
+		}
+	}
+
 	if len(vr.Condition.Failures) > 0 {
 		vr.State = util.Ptr(vapi.ValidationFailed)
 		vr.Condition.Message = fmt.Sprintf("One or more required privileges was not found, or a condition was not met for account: %s", s.username)
@@ -0,0 +1,128 @@
+package privileges
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	"golang.org/x/exp/slices"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+var errRemediationConfig = errors.New("remediation requires roleName and principal")
+
+// remediate creates or updates a role with a rule's privileges and grants it to the remediation principal on the
+// rule's entity, returning a record of the changes made, or planned if the rule's remediation is a dry run
+func (s *PrivilegeValidationService) remediate(ctx context.Context, rule v1alpha1.PrivilegeValidationRule, finder *find.Finder) ([]string, error) {
+	if rule.Remediation.RoleName == "" || rule.Remediation.Principal == "" {
+		return nil, errRemediationConfig
+	}
+
+	ref, err := s.driver.GetPrivilegeRuleEntity(ctx, s.datacenter, finder, rule)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.authManager.RoleList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	permissions, err := s.authManager.RetrieveEntityPermissions(ctx, ref, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch permissions on %s %s: %w", rule.EntityType, rule.EntityName, err)
+	}
+
+	steps, err := planRemediation(s.authManager, rule, ref, roles, permissions)
+	if err != nil {
+		return nil, err
+	}
+	return vsphere.Remediate(ctx, steps, rule.Remediation.DryRun)
+}
+
+// planRemediation returns the steps that create the remediation role, or add the rule's missing privileges to it,
+// and that set a permission granting the role to the remediation principal on the rule's entity. It refuses to replace
+// a permission the principal already holds on the entity with a different role.
+func planRemediation(authManager *object.AuthorizationManager, rule v1alpha1.PrivilegeValidationRule, ref types.ManagedObjectReference, roles object.AuthorizationRoleList, permissions []types.Permission) ([]vsphere.RemediationStep, error) {
+	r := rule.Remediation
+	steps := make([]vsphere.RemediationStep, 0)
+
+	var roleID int32
+	role := roles.ByName(r.RoleName)
+	if role == nil {
+		steps = append(steps, vsphere.RemediationStep{
+			Description: fmt.Sprintf("create role %s with privileges %v", r.RoleName, rule.Privileges),
+			Apply: func(ctx context.Context) error {
+				id, err := authManager.AddRole(ctx, r.RoleName, rule.Privileges)
+				roleID = id
+				return err
+			},
+		})
+	} else {
+		roleID = role.RoleId
+		missing := make([]string, 0)
+		for _, p := range rule.Privileges {
+			if !slices.Contains(role.Privilege, p) {
+				missing = append(missing, p)
+			}
+		}
+		if len(missing) > 0 {
+			privileges := append(slices.Clone(role.Privilege), missing...)
+			steps = append(steps, vsphere.RemediationStep{
+				Description: fmt.Sprintf("add privileges %v to role %s", missing, r.RoleName),
+				Apply: func(ctx context.Context) error {
+					return authManager.UpdateRole(ctx, role.RoleId, role.Name, privileges)
+				},
+			})
+		}
+	}
+
+	propagate := true
+	if rule.Propagation.Enabled {
+		propagate = rule.Propagation.Propagated
+	}
+
+	var existing *types.Permission
+	for i := range permissions {
+		if strings.EqualFold(permissions[i].Principal, r.Principal) {
+			existing = &permissions[i]
+			break
+		}
+	}
+
+	entityDesc := rule.EntityType
+	if rule.EntityName != "" {
+		entityDesc = fmt.Sprintf("%s %s", entityDesc, rule.EntityName)
+	}
+	if existing != nil {
+		if role == nil || existing.RoleId != role.RoleId {
+			previous := fmt.Sprintf("%d", existing.RoleId)
+			if prev := roles.ById(existing.RoleId); prev != nil {
+				previous = prev.Name
+			}
+			return nil, fmt.Errorf("principal %s already has role %s on %s; refusing to replace it with role %s", r.Principal, previous, entityDesc, r.RoleName)
+		}
+		if existing.Propagate == propagate {
+			return steps, nil
+		}
+	}
+	description := fmt.Sprintf("grant role %s to principal %s on %s with propagation %t", r.RoleName, r.Principal, entityDesc, propagate)
+
+	steps = append(steps, vsphere.RemediationStep{
+		Description: description,
+		Apply: func(ctx context.Context) error {
+			return authManager.SetEntityPermissions(ctx, ref, []types.Permission{{
+				Principal: r.Principal,
+				Group:     r.Group,
+				RoleId:    roleID,
+				Propagate: propagate,
+			}})
+		},
+	})
+
+	return steps, nil
+}
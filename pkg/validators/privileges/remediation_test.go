@@ -0,0 +1,126 @@
+package privileges
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	"golang.org/x/exp/slices"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter/entity"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+func TestPrivilegeValidationService_Remediation(t *testing.T) {
+	var log logr.Logger
+
+	vcSim := vcsim.NewVCSim("admin2@vsphere.local", 8477, log)
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	driver, err := vsphere.NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	finder := find.NewFinder(driver.Client.Client)
+	authManager := object.NewAuthorizationManager(driver.Client.Client)
+
+	ctx := context.Background()
+	username, err := driver.CurrentUser(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validationService := NewPrivilegeValidationService(log, driver, vcSim.Options.Datacenter, username, authManager)
+
+	rule := v1alpha1.PrivilegeValidationRule{
+		RuleName:   "Remediated privileges",
+		EntityType: entity.Cluster.String(),
+		EntityName: vcSim.Options.Cluster,
+		Privileges: []string{"VirtualMachine.Config.MagicCarpet"},
+		Remediation: v1alpha1.PrivilegeRemediation{
+			Remediation: v1alpha1.Remediation{Enabled: true, DryRun: true},
+			RoleName:    "k8s-role",
+			Principal:   "k8s-admins",
+			Group:       true,
+		},
+	}
+
+	// dry run plans the changes without applying them
+	vr, err := validationService.ReconcilePrivilegeRule(rule, finder)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"remediation planned (dry run): create role k8s-role with privileges [VirtualMachine.Config.MagicCarpet]",
+		"remediation planned (dry run): grant role k8s-role to principal k8s-admins on Cluster DC0_C0 with propagation true",
+	}, vr.Condition.Details)
+
+	roles, err := authManager.RoleList(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, roles.ByName("k8s-role"))
+
+	// remediation applies the changes. vcsim grants every user all privileges, so remediate directly
+	// with privileges that exist rather than via a failing rule.
+	rule.Privileges = []string{"VirtualMachine.Config.AddNewDisk"}
+	rule.Remediation.DryRun = false
+	record, err := validationService.remediate(ctx, rule, finder)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"remediation applied: create role k8s-role with privileges [VirtualMachine.Config.AddNewDisk]",
+		"remediation applied: grant role k8s-role to principal k8s-admins on Cluster DC0_C0 with propagation true",
+	}, record)
+
+	roles, err = authManager.RoleList(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	role := roles.ByName("k8s-role")
+	if assert.NotNil(t, role) {
+		assert.Contains(t, role.Privilege, "VirtualMachine.Config.AddNewDisk")
+	}
+
+	ref, err := driver.GetPrivilegeRuleEntity(ctx, vcSim.Options.Datacenter, finder, rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	permissions, err := authManager.RetrieveEntityPermissions(ctx, ref, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	granted := false
+	for _, p := range permissions {
+		if p.Principal == "k8s-admins" && p.Group && p.RoleId == role.RoleId && p.Propagate {
+			granted = true
+		}
+	}
+	assert.True(t, granted, "expected permission for k8s-admins on DC0_C0")
+
+	// the role's missing privileges are added and the existing permission is left alone
+	steps, err := planRemediation(authManager, v1alpha1.PrivilegeValidationRule{
+		EntityType:  rule.EntityType,
+		EntityName:  rule.EntityName,
+		Privileges:  []string{"VirtualMachine.Config.AddNewDisk", "VirtualMachine.Config.RemoveDisk"},
+		Remediation: rule.Remediation,
+	}, ref, roles, permissions)
+	assert.NoError(t, err)
+	descriptions := make([]string, 0, len(steps))
+	for _, s := range steps {
+		descriptions = append(descriptions, s.Description)
+	}
+	assert.Equal(t, []string{"add privileges [VirtualMachine.Config.RemoveDisk] to role k8s-role"}, descriptions)
+
+	// a permission the principal holds with a role this code did not create is never replaced
+	admin := roles.ByName("Admin")
+	if admin == nil {
+		t.Fatal("expected vcsim to define the Admin role")
+	}
+	permissions[slices.IndexFunc(permissions, func(p types.Permission) bool { return p.Principal == "k8s-admins" })].RoleId = admin.RoleId
+	_, err = planRemediation(authManager, rule, ref, roles, permissions)
+	assert.EqualError(t, err, "principal k8s-admins already has role Admin on Cluster DC0_C0; refusing to replace it with role k8s-role")
+}
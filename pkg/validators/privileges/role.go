@@ -0,0 +1,438 @@
+package privileges
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter/entity"
+)
+
+// RoleFormat is an output format for a generated role.
+type RoleFormat string
+
+const (
+	// RoleFormatGovc renders a generated role as govc commands.
+	RoleFormatGovc RoleFormat = "govc"
+
+	// RoleFormatPowerCLI renders a generated role as a PowerCLI script.
+	RoleFormatPowerCLI RoleFormat = "powercli"
+
+	// RoleFormatTerraform renders a generated role as Terraform vsphere_role and vsphere_entity_permissions resources.
+	RoleFormatTerraform RoleFormat = "terraform"
+)
+
+// RoleFormats are the supported output formats for a generated role.
+var RoleFormats = []RoleFormat{RoleFormatGovc, RoleFormatPowerCLI, RoleFormatTerraform}
+
+// RoleOptions configures role generation.
+type RoleOptions struct {
+	// RoleName is the name of the generated role. If entities require different privileges, one role is generated
+	// per distinct set of privileges and each role's name is suffixed with its index, e.g., k8s-role-1.
+	RoleName string
+
+	// Principal is the user or group principal that the roles are granted to, e.g., VSPHERE.LOCAL\k8s-admins.
+	Principal string
+
+	// Group indicates whether Principal is a group.
+	Group bool
+}
+
+// Role is a vCenter role and the entities that it is granted on.
+type Role struct {
+	Name        string
+	Privileges  []string
+	Permissions []Permission
+}
+
+// Permission grants a role on a vCenter entity.
+type Permission struct {
+	Entity        entity.Entity
+	Datacenter    string
+	ClusterName   string
+	EntityName    string
+	InventoryPath string
+	Propagate     bool
+}
+
+var identifierPattern = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// GenerateRole returns a least-privilege role definition and permission assignments for a VsphereValidatorSpec's
+// privilege validation rules, rendered in the given format.
+func GenerateRole(spec v1alpha1.VsphereValidatorSpec, opts RoleOptions, format RoleFormat) (string, error) {
+	if opts.RoleName == "" || opts.Principal == "" {
+		return "", fmt.Errorf("role name and principal are required")
+	}
+	roles, err := BuildRoles(spec, opts.RoleName)
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case RoleFormatGovc:
+		return renderGovc(roles, opts), nil
+	case RoleFormatPowerCLI:
+		return renderPowerCLI(roles, opts), nil
+	case RoleFormatTerraform:
+		return renderTerraform(roles, opts), nil
+	default:
+		return "", fmt.Errorf("unsupported role format %s; expected one of %v", format, RoleFormats)
+	}
+}
+
+// BuildRoles unions the privileges required on each entity by a VsphereValidatorSpec's privilege validation rules
+// and returns one role per distinct set of privileges, along with the entities that it must be granted on.
+func BuildRoles(spec v1alpha1.VsphereValidatorSpec, roleName string) ([]Role, error) {
+	permissions := make([]Permission, 0)
+	privileges := make(map[string][]string)
+	explicitPropagation := make(map[string]bool)
+
+	for _, rule := range spec.PrivilegeValidationRules {
+		p, err := newPermission(spec.Datacenter, rule)
+		if err != nil {
+			return nil, err
+		}
+		i := slices.IndexFunc(permissions, func(q Permission) bool { return q.InventoryPath == p.InventoryPath })
+		if i == -1 {
+			permissions = append(permissions, p)
+			i = len(permissions) - 1
+		}
+		if rule.Propagation.Enabled {
+			if explicit, ok := explicitPropagation[p.InventoryPath]; ok && explicit != rule.Propagation.Propagated {
+				return nil, fmt.Errorf("conflicting propagation requirements for %s %s", rule.EntityType, rule.EntityName)
+			}
+			explicitPropagation[p.InventoryPath] = rule.Propagation.Propagated
+			permissions[i].Propagate = rule.Propagation.Propagated
+		}
+		for _, priv := range rule.Privileges {
+			if !slices.Contains(privileges[p.InventoryPath], priv) {
+				privileges[p.InventoryPath] = append(privileges[p.InventoryPath], priv)
+			}
+		}
+	}
+	slices.SortFunc(permissions, func(a, b Permission) int { return strings.Compare(a.InventoryPath, b.InventoryPath) })
+
+	roles := make([]Role, 0)
+	for _, p := range permissions {
+		privs := slices.Clone(privileges[p.InventoryPath])
+		slices.Sort(privs)
+		i := slices.IndexFunc(roles, func(r Role) bool { return slices.Equal(r.Privileges, privs) })
+		if i == -1 {
+			roles = append(roles, Role{Privileges: privs})
+			i = len(roles) - 1
+		}
+		roles[i].Permissions = append(roles[i].Permissions, p)
+	}
+
+	for i := range roles {
+		roles[i].Name = roleName
+		if len(roles) > 1 {
+			roles[i].Name = fmt.Sprintf("%s-%d", roleName, i+1)
+		}
+	}
+	return roles, nil
+}
+
+// newPermission returns the permission for a privilege validation rule's entity. Folders that are not
+// specified by absolute inventory path are assumed to be VM folders.
+func newPermission(datacenter string, rule v1alpha1.PrivilegeValidationRule) (Permission, error) {
+	e, ok := entity.Map[rule.EntityType]
+	if !ok {
+		return Permission{}, fmt.Errorf("unsupported entity type: %s", rule.EntityType)
+	}
+	p := Permission{
+		Entity:      e,
+		Datacenter:  datacenter,
+		ClusterName: rule.ClusterName,
+		EntityName:  rule.EntityName,
+		Propagate:   true,
+	}
+
+	switch e {
+	case entity.Cluster:
+		p.InventoryPath = fmt.Sprintf(vcenter.HostInventoryPath, datacenter, rule.EntityName)
+	case entity.Datacenter:
+		p.InventoryPath = "/" + rule.EntityName
+	case entity.Datastore:
+		p.InventoryPath = fmt.Sprintf(vcenter.DatastoreInventoryPrefix, datacenter) + rule.EntityName
+	case entity.DistributedVirtualPortgroup, entity.DistributedVirtualSwitch, entity.Network:
+		p.InventoryPath = fmt.Sprintf(vcenter.NetworkInventoryPath, datacenter, rule.EntityName)
+	case entity.Folder:
+		p.InventoryPath = rule.EntityName
+		if !strings.HasPrefix(rule.EntityName, "/") {
+			p.InventoryPath = fmt.Sprintf(vcenter.VMFolderInventoryPath, datacenter, rule.EntityName)
+		}
+	case entity.Host:
+		p.InventoryPath = fmt.Sprintf(vcenter.HostInventoryPath, datacenter, rule.EntityName)
+		if rule.ClusterName != "" {
+			p.InventoryPath = fmt.Sprintf(vcenter.HostChildInventoryPath, datacenter, rule.ClusterName, rule.EntityName)
+		}
+	case entity.ResourcePool:
+		p.InventoryPath = fmt.Sprintf(vcenter.ResourcePoolInventoryPath, datacenter, rule.ClusterName, rule.EntityName)
+		if rule.EntityName == vcenter.ClusterDefaultResourcePoolName {
+			p.InventoryPath = fmt.Sprintf(vcenter.HostChildInventoryPath, datacenter, rule.ClusterName, rule.EntityName)
+		}
+	case entity.VCenterRoot:
+		p.InventoryPath = "/"
+	case entity.VirtualApp, entity.VirtualMachine:
+		p.InventoryPath = fmt.Sprintf(vcenter.VMFolderInventoryPath, datacenter, rule.EntityName)
+	}
+	return p, nil
+}
+
+func renderGovc(roles []Role, opts RoleOptions) string {
+	var b strings.Builder
+	for _, r := range roles {
+		fmt.Fprintf(&b, "govc role.create %s %s\n", shellQuote(r.Name), shellList(r.Privileges))
+	}
+	for _, r := range roles {
+		for _, p := range r.Permissions {
+			fmt.Fprintf(&b, "govc permissions.set -principal %s -group=%t -role %s -propagate=%t %s\n",
+				shellQuote(opts.Principal), opts.Group, shellQuote(r.Name), p.Propagate, shellQuote(p.InventoryPath),
+			)
+		}
+	}
+	return b.String()
+}
+
+func renderPowerCLI(roles []Role, opts RoleOptions) string {
+	var b strings.Builder
+	for _, r := range roles {
+		ids := make([]string, 0, len(r.Privileges))
+		for _, priv := range r.Privileges {
+			ids = append(ids, psQuote(priv))
+		}
+		fmt.Fprintf(&b, "New-VIRole -Name %s -Privilege (Get-VIPrivilege -Id %s)\n", psQuote(r.Name), strings.Join(ids, ","))
+	}
+	for _, r := range roles {
+		for _, p := range r.Permissions {
+			fmt.Fprintf(&b, "New-VIPermission -Entity (%s) -Principal %s -Role %s -Propagate:$%t\n",
+				powerCLIEntity(p), psQuote(opts.Principal), psQuote(r.Name), p.Propagate,
+			)
+		}
+	}
+	return b.String()
+}
+
+// powerCLIEntity returns the PowerCLI expression that retrieves a permission's entity
+func powerCLIEntity(p Permission) string {
+	dc := fmt.Sprintf("Get-Datacenter -Name %s", psQuote(p.Datacenter))
+	cluster := fmt.Sprintf("Get-Cluster -Name %s -Location (%s)", psQuote(p.ClusterName), dc)
+	name := psQuote(p.EntityName)
+
+	switch p.Entity {
+	case entity.Cluster:
+		return fmt.Sprintf("Get-Cluster -Name %s -Location (%s)", name, dc)
+	case entity.Datacenter:
+		return fmt.Sprintf("Get-Datacenter -Name %s", name)
+	case entity.Datastore:
+		return fmt.Sprintf("Get-Datastore -Name %s -Location (%s)", name, dc)
+	case entity.DistributedVirtualPortgroup:
+		return fmt.Sprintf("Get-VDPortgroup -Name %s", name)
+	case entity.DistributedVirtualSwitch:
+		return fmt.Sprintf("Get-VDSwitch -Name %s -Location (%s)", name, dc)
+	case entity.Folder:
+		return fmt.Sprintf("Get-Folder -Name %s -Location (%s)", psQuote(p.InventoryPath[strings.LastIndex(p.InventoryPath, "/")+1:]), dc)
+	case entity.Host:
+		if p.ClusterName != "" {
+			return fmt.Sprintf("Get-VMHost -Name %s -Location (%s)", name, cluster)
+		}
+		return fmt.Sprintf("Get-VMHost -Name %s -Location (%s)", name, dc)
+	case entity.Network:
+		return fmt.Sprintf("Get-VirtualNetwork -Name %s -Location (%s)", name, dc)
+	case entity.ResourcePool:
+		return fmt.Sprintf("Get-ResourcePool -Name %s -Location (%s)", name, cluster)
+	case entity.VCenterRoot:
+		return "Get-Folder -NoRecursion"
+	case entity.VirtualApp:
+		return fmt.Sprintf("Get-VApp -Name %s -Location (%s)", name, dc)
+	default:
+		return fmt.Sprintf("Get-VM -Name %s -Location (%s)", name, dc)
+	}
+}
+
+func renderTerraform(roles []Role, opts RoleOptions) string {
+	var b strings.Builder
+	datacenters := make([]string, 0)
+	for _, r := range roles {
+		for _, p := range r.Permissions {
+			if p.Entity != entity.VCenterRoot && !slices.Contains(datacenters, p.Datacenter) {
+				datacenters = append(datacenters, p.Datacenter)
+			}
+			if p.Entity == entity.Datacenter && !slices.Contains(datacenters, p.EntityName) {
+				datacenters = append(datacenters, p.EntityName)
+			}
+		}
+	}
+	for _, dc := range datacenters {
+		writeHCLBlock(&b, fmt.Sprintf("data %q %q", "vsphere_datacenter", identifier(dc)), [][2]string{
+			{"name", hclQuote(dc)},
+		})
+	}
+
+	for _, r := range roles {
+		for _, p := range r.Permissions {
+			source, attrs := terraformEntity(p)
+			if source == "" {
+				continue
+			}
+			writeHCLBlock(&b, fmt.Sprintf("data %q %q", source, identifier(p.InventoryPath)), attrs)
+		}
+	}
+
+	for _, r := range roles {
+		writeHCLBlock(&b, fmt.Sprintf("resource %q %q", "vsphere_role", identifier(r.Name)), [][2]string{
+			{"name", hclQuote(r.Name)},
+			{"role_privileges", hclList(r.Privileges)},
+		})
+	}
+
+	for _, r := range roles {
+		for _, p := range r.Permissions {
+			fmt.Fprintf(&b, "resource %q %q {\n", "vsphere_entity_permissions", identifier(p.InventoryPath))
+			writeHCLAttributes(&b, "  ", [][2]string{
+				{"entity_id", terraformEntityID(p)},
+				{"entity_type", hclQuote(terraformEntityType(p.Entity))},
+			})
+			b.WriteString("  permissions {\n")
+			writeHCLAttributes(&b, "    ", [][2]string{
+				{"user_or_group", hclQuote(opts.Principal)},
+				{"propagate", strconv.FormatBool(p.Propagate)},
+				{"is_group", strconv.FormatBool(opts.Group)},
+				{"role_id", fmt.Sprintf("vsphere_role.%s.id", identifier(r.Name))},
+			})
+			b.WriteString("  }\n}\n\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// terraformEntity returns the Terraform data source and its attributes that look up a permission's entity.
+// Datacenters are looked up separately.
+func terraformEntity(p Permission) (string, [][2]string) {
+	dcID := fmt.Sprintf("data.vsphere_datacenter.%s.id", identifier(p.Datacenter))
+	named := func(source, name string) (string, [][2]string) {
+		return source, [][2]string{{"name", hclQuote(name)}, {"datacenter_id", dcID}}
+	}
+
+	switch p.Entity {
+	case entity.Cluster:
+		return named("vsphere_compute_cluster", p.EntityName)
+	case entity.Datacenter:
+		return "", nil
+	case entity.Datastore:
+		return named("vsphere_datastore", p.EntityName)
+	case entity.DistributedVirtualPortgroup, entity.Network:
+		return named("vsphere_network", p.EntityName)
+	case entity.DistributedVirtualSwitch:
+		return named("vsphere_distributed_virtual_switch", p.EntityName)
+	case entity.Folder, entity.VCenterRoot:
+		return "vsphere_folder", [][2]string{{"path", hclQuote(p.InventoryPath)}}
+	case entity.Host:
+		return named("vsphere_host", p.EntityName)
+	case entity.ResourcePool:
+		return named("vsphere_resource_pool", strings.TrimPrefix(p.InventoryPath, fmt.Sprintf(vcenter.HostInventoryPrefix, p.Datacenter)))
+	case entity.VirtualApp:
+		return named("vsphere_vapp_container", p.EntityName)
+	default:
+		return named("vsphere_virtual_machine", p.EntityName)
+	}
+}
+
+// terraformEntityID returns the Terraform expression for the ID of a permission's entity
+func terraformEntityID(p Permission) string {
+	if p.Entity == entity.Datacenter {
+		return fmt.Sprintf("data.vsphere_datacenter.%s.id", identifier(p.EntityName))
+	}
+	source, _ := terraformEntity(p)
+	return fmt.Sprintf("data.%s.%s.id", source, identifier(p.InventoryPath))
+}
+
+// terraformEntityType returns the managed object type of an entity
+func terraformEntityType(e entity.Entity) string {
+	switch e {
+	case entity.Cluster:
+		return "ClusterComputeResource"
+	case entity.Datacenter:
+		return "Datacenter"
+	case entity.Datastore:
+		return "Datastore"
+	case entity.DistributedVirtualPortgroup:
+		return "DistributedVirtualPortgroup"
+	case entity.DistributedVirtualSwitch:
+		return "VmwareDistributedVirtualSwitch"
+	case entity.Folder, entity.VCenterRoot:
+		return "Folder"
+	case entity.Host:
+		return "HostSystem"
+	case entity.Network:
+		return "Network"
+	case entity.ResourcePool:
+		return "ResourcePool"
+	case entity.VirtualApp:
+		return "VirtualApp"
+	default:
+		return "VirtualMachine"
+	}
+}
+
+func writeHCLBlock(b *strings.Builder, header string, attrs [][2]string) {
+	fmt.Fprintf(b, "%s {\n", header)
+	writeHCLAttributes(b, "  ", attrs)
+	b.WriteString("}\n\n")
+}
+
+// writeHCLAttributes writes attributes with their equals signs aligned, as terraform fmt does
+func writeHCLAttributes(b *strings.Builder, indent string, attrs [][2]string) {
+	width := 0
+	for _, a := range attrs {
+		width = max(width, len(a[0]))
+	}
+	for _, a := range attrs {
+		fmt.Fprintf(b, "%s%-*s = %s\n", indent, width, a[0], a[1])
+	}
+}
+
+// identifier returns a Terraform identifier for a name
+func identifier(name string) string {
+	id := strings.Trim(identifierPattern.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if id == "" {
+		return "root"
+	}
+	if id[0] >= '0' && id[0] <= '9' {
+		id = "_" + id
+	}
+	return id
+}
+
+func hclQuote(s string) string {
+	return strings.ReplaceAll(strconv.Quote(s), "${", "$${")
+}
+
+func hclList(items []string) string {
+	quoted := make([]string, 0, len(items))
+	for _, i := range items {
+		quoted = append(quoted, hclQuote(i))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func shellList(items []string) string {
+	quoted := make([]string, 0, len(items))
+	for _, i := range items {
+		quoted = append(quoted, shellQuote(i))
+	}
+	return strings.Join(quoted, " ")
+}
+
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,135 @@
+package privileges
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter/entity"
+)
+
+func TestGenerateRole(t *testing.T) {
+	spec := v1alpha1.VsphereValidatorSpec{
+		Datacenter: "DC0",
+		PrivilegeValidationRules: []v1alpha1.PrivilegeValidationRule{
+			{
+				RuleName:   "Cluster privileges",
+				EntityType: entity.Cluster.String(),
+				EntityName: "DC0_C0",
+				Privileges: []string{"Resource.AssignVMToPool", "Host.Inventory.EditCluster"},
+			},
+			{
+				RuleName:   "More cluster privileges",
+				EntityType: entity.Cluster.String(),
+				EntityName: "DC0_C0",
+				Privileges: []string{"Host.Inventory.EditCluster", "Resource.AssignVMToPool"},
+			},
+			{
+				RuleName:    "Folder privileges",
+				EntityType:  entity.Folder.String(),
+				EntityName:  "k8s",
+				Privileges:  []string{"VirtualMachine.Config.AddNewDisk"},
+				Propagation: v1alpha1.Propagation{Enabled: true, Propagated: false},
+			},
+		},
+	}
+	opts := RoleOptions{RoleName: "k8s-role", Principal: `VSPHERE.LOCAL\k8s-admins`, Group: true}
+
+	testCases := []struct {
+		name     string
+		spec     v1alpha1.VsphereValidatorSpec
+		format   RoleFormat
+		expected string
+		err      string
+	}{
+		{
+			name:   "govc",
+			spec:   spec,
+			format: RoleFormatGovc,
+			expected: `govc role.create 'k8s-role-1' 'Host.Inventory.EditCluster' 'Resource.AssignVMToPool'
+govc role.create 'k8s-role-2' 'VirtualMachine.Config.AddNewDisk'
+govc permissions.set -principal 'VSPHERE.LOCAL\k8s-admins' -group=true -role 'k8s-role-1' -propagate=true '/DC0/host/DC0_C0'
+govc permissions.set -principal 'VSPHERE.LOCAL\k8s-admins' -group=true -role 'k8s-role-2' -propagate=false '/DC0/vm/k8s'
+`,
+		},
+		{
+			name:   "powercli",
+			spec:   spec,
+			format: RoleFormatPowerCLI,
+			expected: `New-VIRole -Name 'k8s-role-1' -Privilege (Get-VIPrivilege -Id 'Host.Inventory.EditCluster','Resource.AssignVMToPool')
+New-VIRole -Name 'k8s-role-2' -Privilege (Get-VIPrivilege -Id 'VirtualMachine.Config.AddNewDisk')
+New-VIPermission -Entity (Get-Cluster -Name 'DC0_C0' -Location (Get-Datacenter -Name 'DC0')) -Principal 'VSPHERE.LOCAL\k8s-admins' -Role 'k8s-role-1' -Propagate:$true
+New-VIPermission -Entity (Get-Folder -Name 'k8s' -Location (Get-Datacenter -Name 'DC0')) -Principal 'VSPHERE.LOCAL\k8s-admins' -Role 'k8s-role-2' -Propagate:$false
+`,
+		},
+		{
+			name: "terraform",
+			spec: v1alpha1.VsphereValidatorSpec{
+				Datacenter:               "DC0",
+				PrivilegeValidationRules: spec.PrivilegeValidationRules[:2],
+			},
+			format: RoleFormatTerraform,
+			expected: `data "vsphere_datacenter" "dc0" {
+  name = "DC0"
+}
+
+data "vsphere_compute_cluster" "dc0_host_dc0_c0" {
+  name          = "DC0_C0"
+  datacenter_id = data.vsphere_datacenter.dc0.id
+}
+
+resource "vsphere_role" "k8s_role" {
+  name            = "k8s-role"
+  role_privileges = ["Host.Inventory.EditCluster", "Resource.AssignVMToPool"]
+}
+
+resource "vsphere_entity_permissions" "dc0_host_dc0_c0" {
+  entity_id   = data.vsphere_compute_cluster.dc0_host_dc0_c0.id
+  entity_type = "ClusterComputeResource"
+  permissions {
+    user_or_group = "VSPHERE.LOCAL\\k8s-admins"
+    propagate     = true
+    is_group      = true
+    role_id       = vsphere_role.k8s_role.id
+  }
+}
+`,
+		},
+		{
+			name: "conflicting propagation",
+			spec: v1alpha1.VsphereValidatorSpec{
+				Datacenter: "DC0",
+				PrivilegeValidationRules: []v1alpha1.PrivilegeValidationRule{
+					spec.PrivilegeValidationRules[2],
+					{
+						RuleName:    "Propagated folder privileges",
+						EntityType:  entity.Folder.String(),
+						EntityName:  "k8s",
+						Privileges:  []string{"VirtualMachine.Config.RemoveDisk"},
+						Propagation: v1alpha1.Propagation{Enabled: true, Propagated: true},
+					},
+				},
+			},
+			format: RoleFormatGovc,
+			err:    "conflicting propagation requirements for Folder k8s",
+		},
+		{
+			name:   "unsupported format",
+			spec:   spec,
+			format: "ansible",
+			err:    "unsupported role format ansible; expected one of [govc powercli terraform]",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := GenerateRole(tc.spec, opts, tc.format)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
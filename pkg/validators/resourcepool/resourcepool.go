@@ -0,0 +1,106 @@
+// Package resourcepool handles resource pool configuration validation rule reconciliation.
+package resourcepool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/find"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	vapiconstants "github.com/validator-labs/validator/pkg/constants"
+	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+// ValidationService is a service that validates resource pool rules
+type ValidationService struct {
+	log        logr.Logger
+	driver     *vsphere.VCenterDriver
+	datacenter string
+}
+
+// NewValidationService creates a new ValidationService
+func NewValidationService(log logr.Logger, driver *vsphere.VCenterDriver, datacenter string) *ValidationService {
+	return &ValidationService{
+		log:        log,
+		driver:     driver,
+		datacenter: datacenter,
+	}
+}
+
+func buildValidationResult(rule v1alpha1.ResourcePoolValidationRule) *types.ValidationRuleResult {
+	state := vapi.ValidationSucceeded
+	validationType := constants.ValidationTypeResourcePool
+
+	validationRule := fmt.Sprintf("%s-%s-%s", vapiconstants.ValidationRulePrefix, validationType, rule.Name())
+
+	latestCondition := vapi.DefaultValidationCondition()
+	latestCondition.Message = fmt.Sprintf("Resource pool %s has the expected configuration", rule.ResourcePool)
+	latestCondition.ValidationRule = util.Sanitize(validationRule)
+	latestCondition.ValidationType = validationType
+
+	return &types.ValidationRuleResult{Condition: &latestCondition, State: &state}
+}
+
+// ReconcileResourcePoolRule reconciles a resource pool rule
+func (s *ValidationService) ReconcileResourcePoolRule(rule v1alpha1.ResourcePoolValidationRule, finder *find.Finder) (*types.ValidationRuleResult, error) {
+	vr := buildValidationResult(rule)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	failures := make([]string, 0)
+
+	cfg, err := s.driver.GetResourcePoolConfig(ctx, finder, s.datacenter, rule.ClusterName, rule.ResourcePool)
+	if err != nil {
+		var notFoundErr *find.NotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return vr, err
+		}
+		failures = append(failures, fmt.Sprintf("resource pool %s not found in cluster %s", rule.ResourcePool, rule.ClusterName))
+	} else {
+		failures = append(failures, validateAllocation("CPU", "MHz", rule.CPU, cfg.CPU)...)
+		failures = append(failures, validateAllocation("memory", "MB", rule.Memory, cfg.Memory)...)
+	}
+
+	if len(failures) > 0 {
+		vr.State = util.Ptr(vapi.ValidationFailed)
+		vr.Condition.Failures = failures
+		vr.Condition.Message = fmt.Sprintf("Resource pool %s does not have the expected configuration", rule.ResourcePool)
+		vr.Condition.Status = corev1.ConditionFalse
+	}
+
+	return vr, nil
+}
+
+// validateAllocation compares a resource pool's allocation of a resource to the expected allocation
+func validateAllocation(resource, unit string, req *v1alpha1.ResourceAllocationRequirement, allocation vcenter.ResourceAllocation) []string {
+	failures := make([]string, 0)
+	if req == nil {
+		return failures
+	}
+
+	if allocation.Reservation < req.MinReservation {
+		failures = append(failures, fmt.Sprintf("%s reservation %d %s is less than %d %s", resource, allocation.Reservation, unit, req.MinReservation, unit))
+	}
+	if req.MinLimit > 0 && allocation.Limit != -1 && allocation.Limit < req.MinLimit {
+		failures = append(failures, fmt.Sprintf("%s limit %d %s is less than %d %s", resource, allocation.Limit, unit, req.MinLimit, unit))
+	}
+	if req.SharesLevel != "" && allocation.SharesLevel != req.SharesLevel {
+		failures = append(failures, fmt.Sprintf("%s shares level is %s, expected %s", resource, allocation.SharesLevel, req.SharesLevel))
+	}
+	if req.ExpandableReservation != nil && allocation.ExpandableReservation != *req.ExpandableReservation {
+		failures = append(failures, fmt.Sprintf("%s expandable reservation is %t, expected %t", resource, allocation.ExpandableReservation, *req.ExpandableReservation))
+	}
+
+	return failures
+}
@@ -0,0 +1,66 @@
+package resourcepool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+func TestValidateAllocation(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        *v1alpha1.ResourceAllocationRequirement
+		allocation vcenter.ResourceAllocation
+		expected   []string
+	}{
+		{
+			name:       "Pass without requirement",
+			req:        nil,
+			allocation: vcenter.ResourceAllocation{Limit: 100},
+			expected:   []string{},
+		},
+		{
+			name: "Pass with unlimited limit",
+			req: &v1alpha1.ResourceAllocationRequirement{
+				MinReservation:        1000,
+				MinLimit:              4000,
+				SharesLevel:           "high",
+				ExpandableReservation: util.Ptr(true),
+			},
+			allocation: vcenter.ResourceAllocation{Reservation: 1000, Limit: -1, SharesLevel: "high", ExpandableReservation: true},
+			expected:   []string{},
+		},
+		{
+			name:       "Pass with limit above threshold",
+			req:        &v1alpha1.ResourceAllocationRequirement{MinLimit: 4000},
+			allocation: vcenter.ResourceAllocation{Limit: 4000, SharesLevel: "normal"},
+			expected:   []string{},
+		},
+		{
+			name: "Fail",
+			req: &v1alpha1.ResourceAllocationRequirement{
+				MinReservation:        1000,
+				MinLimit:              4000,
+				SharesLevel:           "high",
+				ExpandableReservation: util.Ptr(false),
+			},
+			allocation: vcenter.ResourceAllocation{Reservation: 500, Limit: 2000, SharesLevel: "normal", ExpandableReservation: true},
+			expected: []string{
+				"CPU reservation 500 MHz is less than 1000 MHz",
+				"CPU limit 2000 MHz is less than 4000 MHz",
+				"CPU shares level is normal, expected high",
+				"CPU expandable reservation is true, expected false",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validateAllocation("CPU", "MHz", tt.req, tt.allocation))
+		})
+	}
+}
@@ -0,0 +1,209 @@
+// Package supervisor handles vSphere with Tanzu Supervisor and vSphere Namespace validation rule reconciliation.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/find"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	vapiconstants "github.com/validator-labs/validator/pkg/constants"
+	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+const (
+	configStatusRunning   = "RUNNING"
+	kubernetesStatusReady = "READY"
+	hertzPerMegahertz     = 1000 * 1000
+	bytesPerMebibyte      = 1024 * 1024
+)
+
+// ValidationService is a service that validates Supervisor rules
+type ValidationService struct {
+	log        logr.Logger
+	driver     *vsphere.VCenterDriver
+	datacenter string
+}
+
+// NewValidationService creates a new ValidationService
+func NewValidationService(log logr.Logger, driver *vsphere.VCenterDriver, datacenter string) *ValidationService {
+	return &ValidationService{
+		log:        log,
+		driver:     driver,
+		datacenter: datacenter,
+	}
+}
+
+// quota is the CPU, memory and storage required by a set of node pools
+type quota struct {
+	CPUMHz     int64
+	MemoryMiB  int64
+	StorageMiB int64
+}
+
+func buildValidationResult(rule v1alpha1.SupervisorValidationRule) *types.ValidationRuleResult {
+	state := vapi.ValidationSucceeded
+	validationType := constants.ValidationTypeSupervisor
+
+	validationRule := fmt.Sprintf("%s-%s-%s", vapiconstants.ValidationRulePrefix, validationType, rule.Name())
+
+	latestCondition := vapi.DefaultValidationCondition()
+	latestCondition.Message = fmt.Sprintf("Supervisor on cluster %s is ready", rule.ClusterName)
+	latestCondition.ValidationRule = util.Sanitize(validationRule)
+	latestCondition.ValidationType = validationType
+
+	return &types.ValidationRuleResult{Condition: &latestCondition, State: &state}
+}
+
+// ReconcileSupervisorRule reconciles a Supervisor rule
+func (s *ValidationService) ReconcileSupervisorRule(rule v1alpha1.SupervisorValidationRule, finder *find.Finder) (*types.ValidationRuleResult, error) {
+	vr := buildValidationResult(rule)
+
+	required, err := nodepoolQuota(rule.NodepoolResourceRequirements)
+	if err != nil {
+		return vr, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	failures := make([]string, 0)
+
+	sc, err := s.driver.GetSupervisorCluster(ctx, finder, s.datacenter, rule.ClusterName)
+	if err != nil {
+		var notFoundErr *find.NotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return vr, err
+		}
+		failures = append(failures, fmt.Sprintf("cluster %s not found", rule.ClusterName))
+	} else if !sc.Enabled {
+		failures = append(failures, fmt.Sprintf("Workload Management is not enabled on cluster %s", rule.ClusterName))
+	} else {
+		failures = append(failures, validateSupervisor(sc)...)
+
+		if rule.NamespaceName != "" {
+			ns, err := s.driver.GetNamespace(ctx, rule.NamespaceName)
+			if err != nil {
+				return vr, err
+			}
+			failures = append(failures, validateNamespace(rule, sc, ns, required)...)
+		}
+	}
+
+	if len(failures) > 0 {
+		vr.State = util.Ptr(vapi.ValidationFailed)
+		vr.Condition.Failures = failures
+		vr.Condition.Message = fmt.Sprintf("Supervisor or vSphere Namespace on cluster %s is not ready", rule.ClusterName)
+		vr.Condition.Status = corev1.ConditionFalse
+	}
+
+	return vr, nil
+}
+
+// validateSupervisor ensures that the Supervisor is running and its Kubernetes control plane is ready
+func validateSupervisor(sc *vcenter.SupervisorCluster) []string {
+	failures := make([]string, 0)
+
+	if sc.ConfigStatus != configStatusRunning {
+		failures = append(failures, fmt.Sprintf("Supervisor config status is %s, expected %s", sc.ConfigStatus, configStatusRunning))
+	}
+	if sc.KubernetesStatus != kubernetesStatusReady {
+		failures = append(failures, fmt.Sprintf("Supervisor Kubernetes status is %s, expected %s", sc.KubernetesStatus, kubernetesStatusReady))
+	}
+
+	return failures
+}
+
+// validateNamespace ensures that the vSphere Namespace exists on the Supervisor, is running, has the required
+// storage policies and VM classes bound, and that its quotas leave room for the required resources
+func validateNamespace(rule v1alpha1.SupervisorValidationRule, sc *vcenter.SupervisorCluster, ns *vcenter.Namespace, required quota) []string {
+	if ns == nil || ns.ClusterID != sc.ClusterID {
+		return []string{fmt.Sprintf("vSphere Namespace %s not found on cluster %s", rule.NamespaceName, rule.ClusterName)}
+	}
+
+	failures := make([]string, 0)
+	if ns.ConfigStatus != configStatusRunning {
+		failures = append(failures, fmt.Sprintf("vSphere Namespace %s config status is %s, expected %s", ns.Name, ns.ConfigStatus, configStatusRunning))
+	}
+	if missing := missingElements(ns.StoragePolicies, rule.StoragePolicies); len(missing) > 0 {
+		failures = append(failures, fmt.Sprintf("vSphere Namespace %s is missing storage policies %s", ns.Name, strings.Join(missing, ", ")))
+	}
+	if missing := missingElements(ns.VMClasses, rule.VMClasses); len(missing) > 0 {
+		failures = append(failures, fmt.Sprintf("vSphere Namespace %s is missing VM classes %s", ns.Name, strings.Join(missing, ", ")))
+	}
+
+	if problem := validateQuota("CPU", "MHz", ns.CPULimitMHz, ns.CPUUsedMHz, required.CPUMHz); problem != "" {
+		failures = append(failures, fmt.Sprintf("vSphere Namespace %s: %s", ns.Name, problem))
+	}
+	if problem := validateQuota("memory", "MiB", ns.MemoryLimitMiB, ns.MemoryUsedMiB, required.MemoryMiB); problem != "" {
+		failures = append(failures, fmt.Sprintf("vSphere Namespace %s: %s", ns.Name, problem))
+	}
+	if problem := validateQuota("storage", "MiB", ns.StorageLimitMiB, ns.StorageUsedMiB, required.StorageMiB); problem != "" {
+		failures = append(failures, fmt.Sprintf("vSphere Namespace %s: %s", ns.Name, problem))
+	}
+
+	return failures
+}
+
+// validateQuota ensures that a quota leaves room for the required amount. A limit of zero denotes an unlimited quota.
+func validateQuota(resourceName, unit string, limit, used, required int64) string {
+	if limit == 0 || required == 0 {
+		return ""
+	}
+	if free := limit - used; free < required {
+		return fmt.Sprintf("%s quota has %d %s free of %d %s, expected at least %d %s", resourceName, free, unit, limit, unit, required, unit)
+	}
+	return ""
+}
+
+// nodepoolQuota sums the CPU, memory and storage required by all nodes of the node pools
+func nodepoolQuota(requirements []v1alpha1.NodepoolResourceRequirement) (quota, error) {
+	var q quota
+	for _, r := range requirements {
+		cpu, err := resource.ParseQuantity(strings.TrimSuffix(r.CPU, "Hz"))
+		if err != nil {
+			return q, fmt.Errorf("invalid CPU requirement %s of node pool %s: %w", r.CPU, r.Name, err)
+		}
+		memory, err := resource.ParseQuantity(strings.TrimSuffix(r.Memory, "B"))
+		if err != nil {
+			return q, fmt.Errorf("invalid memory requirement %s of node pool %s: %w", r.Memory, r.Name, err)
+		}
+		disk, err := resource.ParseQuantity(strings.TrimSuffix(r.DiskSpace, "B"))
+		if err != nil {
+			return q, fmt.Errorf("invalid disk space requirement %s of node pool %s: %w", r.DiskSpace, r.Name, err)
+		}
+
+		nodes := int64(r.NumberOfNodes)
+		q.CPUMHz += nodes * cpu.Value() / hertzPerMegahertz
+		q.MemoryMiB += nodes * memory.Value() / bytesPerMebibyte
+		q.StorageMiB += nodes * disk.Value() / bytesPerMebibyte
+	}
+	return q, nil
+}
+
+// missingElements returns the expected elements that are not in actual
+func missingElements(actual, expected []string) []string {
+	present := make(map[string]bool, len(actual))
+	for _, a := range actual {
+		present[a] = true
+	}
+	missing := make([]string, 0)
+	for _, e := range expected {
+		if !present[e] {
+			missing = append(missing, e)
+		}
+	}
+	return missing
+}
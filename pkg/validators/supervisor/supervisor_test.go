@@ -0,0 +1,108 @@
+package supervisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+func TestValidateSupervisor(t *testing.T) {
+	assert.Equal(t, []string{}, validateSupervisor(&vcenter.SupervisorCluster{
+		Enabled: true, ConfigStatus: "RUNNING", KubernetesStatus: "READY",
+	}))
+	assert.Equal(t, []string{
+		"Supervisor config status is CONFIGURING, expected RUNNING",
+		"Supervisor Kubernetes status is WARNING, expected READY",
+	}, validateSupervisor(&vcenter.SupervisorCluster{
+		Enabled: true, ConfigStatus: "CONFIGURING", KubernetesStatus: "WARNING",
+	}))
+}
+
+func TestValidateNamespace(t *testing.T) {
+	sc := &vcenter.SupervisorCluster{ClusterID: "domain-c8", Enabled: true}
+	rule := v1alpha1.SupervisorValidationRule{
+		ClusterName:     "Cluster2",
+		NamespaceName:   "tkg",
+		StoragePolicies: []string{"vSAN Default Storage Policy", "gold"},
+		VMClasses:       []string{"best-effort-small", "guaranteed-large"},
+	}
+	ns := &vcenter.Namespace{
+		Name:            "tkg",
+		ClusterID:       "domain-c8",
+		ConfigStatus:    "RUNNING",
+		StoragePolicies: []string{"vSAN Default Storage Policy", "gold"},
+		VMClasses:       []string{"best-effort-small", "guaranteed-large"},
+		CPULimitMHz:     10000,
+		CPUUsedMHz:      4000,
+		MemoryLimitMiB:  65536,
+		MemoryUsedMiB:   32768,
+	}
+
+	tests := []struct {
+		name     string
+		ns       *vcenter.Namespace
+		required quota
+		expected []string
+	}{
+		{
+			name:     "Pass",
+			ns:       ns,
+			required: quota{CPUMHz: 6000, MemoryMiB: 32768, StorageMiB: 1 << 20},
+			expected: []string{},
+		},
+		{
+			name:     "Fail with missing namespace",
+			ns:       nil,
+			expected: []string{"vSphere Namespace tkg not found on cluster Cluster2"},
+		},
+		{
+			name: "Fail with namespace on another cluster",
+			ns: &vcenter.Namespace{
+				Name: "tkg", ClusterID: "domain-c9", ConfigStatus: "RUNNING",
+			},
+			expected: []string{"vSphere Namespace tkg not found on cluster Cluster2"},
+		},
+		{
+			name: "Fail with missing bindings and insufficient quota",
+			ns: &vcenter.Namespace{
+				Name:            "tkg",
+				ClusterID:       "domain-c8",
+				ConfigStatus:    "ERROR",
+				StoragePolicies: []string{"gold"},
+				VMClasses:       []string{},
+				CPULimitMHz:     10000,
+				CPUUsedMHz:      4000,
+			},
+			required: quota{CPUMHz: 6001},
+			expected: []string{
+				"vSphere Namespace tkg config status is ERROR, expected RUNNING",
+				"vSphere Namespace tkg is missing storage policies vSAN Default Storage Policy",
+				"vSphere Namespace tkg is missing VM classes best-effort-small, guaranteed-large",
+				"vSphere Namespace tkg: CPU quota has 6000 MHz free of 10000 MHz, expected at least 6001 MHz",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validateNamespace(rule, sc, tt.ns, tt.required))
+		})
+	}
+}
+
+func TestNodepoolQuota(t *testing.T) {
+	q, err := nodepoolQuota([]v1alpha1.NodepoolResourceRequirement{
+		{Name: "control-plane-pool", NumberOfNodes: 3, CPU: "2GHz", Memory: "8Gi", DiskSpace: "40Gi"},
+		{Name: "worker-pool", NumberOfNodes: 2, CPU: "4GHz", Memory: "16GiB", DiskSpace: "80Gi"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, quota{CPUMHz: 14000, MemoryMiB: 57344, StorageMiB: 286720}, q)
+
+	_, err = nodepoolQuota([]v1alpha1.NodepoolResourceRequirement{
+		{Name: "worker-pool", NumberOfNodes: 1, CPU: "lots", Memory: "8Gi", DiskSpace: "40Gi"},
+	})
+	assert.Error(t, err)
+}
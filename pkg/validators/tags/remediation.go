@@ -0,0 +1,141 @@
+package tags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25/mo"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+const defaultCardinality = "SINGLE"
+
+var errRemediationUnsupported = errors.New("remediation is only supported for rules that require a tag to be attached")
+
+// remediate creates a rule's missing tag category and tag and attaches the tag to the rule's entity,
+// returning a record of the changes made, or planned if the rule's remediation is a dry run
+func remediate(tagsManager *tags.Manager, finder *find.Finder, datacenter string, rule v1alpha1.TagValidationRule) ([]string, error) {
+	ctx := context.TODO()
+
+	category, ref, entityTags, err := lookupTags(tagsManager, finder, datacenter, rule)
+	if err != nil {
+		return nil, err
+	}
+	if ref == nil {
+		return nil, fmt.Errorf("%s %s was not found", rule.EntityType, rule.EntityName)
+	}
+
+	var categoryTags []tags.Tag
+	if category != nil {
+		categoryTags, err = tagsManager.GetTagsForCategory(ctx, category.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	steps, err := planRemediation(tagsManager, rule, category, categoryTags, ref, entityTags)
+	if err != nil {
+		return nil, err
+	}
+	return vsphere.Remediate(ctx, steps, rule.Remediation.DryRun)
+}
+
+// planRemediation returns the steps that create a rule's missing tag category and tag, detach conflicting tags
+// from a single cardinality category, and attach the tag to the rule's entity
+func planRemediation(tagsManager *tags.Manager, rule v1alpha1.TagValidationRule, category *tags.Category, categoryTags []tags.Tag, ref mo.Reference, entityTags []tags.Tag) ([]vsphere.RemediationStep, error) {
+	if rule.Absent {
+		return nil, errRemediationUnsupported
+	}
+
+	tagName := rule.TagName
+	if tagName == "" && len(rule.AllowedTagNames) > 0 {
+		tagName = rule.AllowedTagNames[0]
+	}
+	if tagName == "" {
+		return nil, errors.New("remediation requires tagName or allowedTagNames")
+	}
+	if rule.TagNamePattern != "" {
+		pattern, err := regexp.Compile(rule.TagNamePattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag name pattern %s: %w", rule.TagNamePattern, err)
+		}
+		if !pattern.MatchString(tagName) {
+			return nil, fmt.Errorf("tag %s does not match tag name pattern %s", tagName, rule.TagNamePattern)
+		}
+	}
+
+	entityDesc := fmt.Sprintf("%s %s", rule.EntityType, rule.EntityName)
+	steps := make([]vsphere.RemediationStep, 0)
+
+	var categoryID, tagID string
+	if category == nil {
+		cardinality := strings.ToUpper(rule.Cardinality)
+		if cardinality == "" {
+			cardinality = defaultCardinality
+		}
+		steps = append(steps, vsphere.RemediationStep{
+			Description: fmt.Sprintf("create tag category %s with cardinality %s", rule.Tag, cardinality),
+			Apply: func(ctx context.Context) error {
+				id, err := tagsManager.CreateCategory(ctx, &tags.Category{Name: rule.Tag, Cardinality: cardinality})
+				categoryID = id
+				return err
+			},
+		})
+	} else {
+		categoryID = category.ID
+		for _, t := range categoryTags {
+			if t.Name == tagName {
+				tagID = t.ID
+				break
+			}
+		}
+	}
+
+	if tagID == "" {
+		steps = append(steps, vsphere.RemediationStep{
+			Description: fmt.Sprintf("create tag %s in category %s", tagName, rule.Tag),
+			Apply: func(ctx context.Context) error {
+				id, err := tagsManager.CreateTag(ctx, &tags.Tag{Name: tagName, CategoryID: categoryID})
+				tagID = id
+				return err
+			},
+		})
+	}
+
+	if category != nil {
+		for _, t := range entityTags {
+			if t.CategoryID != category.ID {
+				continue
+			}
+			if t.Name == tagName {
+				// the tag is already attached, so its failures are not remediable, e.g., a cardinality mismatch
+				return steps, nil
+			}
+			if strings.EqualFold(category.Cardinality, defaultCardinality) {
+				id, name := t.ID, t.Name
+				steps = append(steps, vsphere.RemediationStep{
+					Description: fmt.Sprintf("detach tag %s in category %s from %s", name, rule.Tag, entityDesc),
+					Apply: func(ctx context.Context) error {
+						return tagsManager.DetachTag(ctx, id, ref)
+					},
+				})
+			}
+		}
+	}
+
+	steps = append(steps, vsphere.RemediationStep{
+		Description: fmt.Sprintf("attach tag %s in category %s to %s", tagName, rule.Tag, entityDesc),
+		Apply: func(ctx context.Context) error {
+			return tagsManager.AttachTag(ctx, tagID, ref)
+		},
+	})
+
+	return steps, nil
+}
@@ -5,11 +5,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/vapi/tags"
 	"github.com/vmware/govmomi/vim25/mo"
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 
 	vapi "github.com/validator-labs/validator/api/v1alpha1"
@@ -25,6 +28,8 @@ import (
 )
 
 var (
+	errEntityTagsNotFound = errors.New("entity tags don't exist")
+
 	// GetCategories is defined to enable monkey patching the getCategories function in integration tests
 	GetCategories = getCategories
 
@@ -58,14 +63,27 @@ func NewValidationService(log logr.Logger) *ValidationService {
 func (s *ValidationService) ReconcileTagRules(tagsManager *tags.Manager, finder *find.Finder, driver *vsphere.VCenterDriver, rule v1alpha1.TagValidationRule) (*vapitypes.ValidationRuleResult, error) {
 	vr := buildValidationResult(rule)
 
-	valid, err := tagIsValid(tagsManager, finder, driver.Datacenter, rule)
-	if !valid {
+	failures, err := tagIsValid(tagsManager, finder, driver.Datacenter, rule)
+	if len(failures) > 0 && rule.Remediation.Enabled {
+		record, rerr := remediate(tagsManager, finder, driver.Datacenter, rule)
+		vr.Condition.Details = append(vr.Condition.Details, record...)
+		switch {
+		case rerr != nil:
+			failures = append(failures, fmt.Sprintf("remediation failed: %s", rerr))
+		case !rule.Remediation.DryRun:
+			failures, err = tagIsValid(tagsManager, finder, driver.Datacenter, rule)
+		}
+	}
+	if len(failures) > 0 {
 		vr.State = util.Ptr(vapi.ValidationFailed)
-		vr.Condition.Failures = append(vr.Condition.Failures, "One or more required tags was not found")
-		vr.Condition.Message = "One or more required tags was not found"
+		vr.Condition.Failures = append(vr.Condition.Failures, failures...)
+		vr.Condition.Message = "One or more tag requirements were not satisfied"
 		vr.Condition.Status = corev1.ConditionFalse
 		return vr, err
 	}
+	if err != nil {
+		return vr, err
+	}
 
 	s.Log.V(0).Info("Entity tags exist")
 	return vr, nil
@@ -75,7 +93,11 @@ func buildValidationResult(rule v1alpha1.TagValidationRule) *vapitypes.Validatio
 	state := vapi.ValidationSucceeded
 	validationType := constants.ValidationTypeTag
 
+	// Rules that only require a category keep their original result name, so that existing conditions are updated
 	validationRule := fmt.Sprintf("%s-%s-%s-%s", vapiconstants.ValidationRulePrefix, validationType, rule.EntityType, rule.Tag)
+	if hasTagConstraints(rule) {
+		validationRule = fmt.Sprintf("%s-%s-%s", vapiconstants.ValidationRulePrefix, validationType, rule.Name())
+	}
 
 	latestCondition := vapi.DefaultValidationCondition()
 	latestCondition.Message = "Required entity tags were found"
@@ -85,18 +107,40 @@ func buildValidationResult(rule v1alpha1.TagValidationRule) *vapitypes.Validatio
 	return &vapitypes.ValidationRuleResult{Condition: &latestCondition, State: &state}
 }
 
-func tagIsValid(tagsManager *tags.Manager, finder *find.Finder, datacenter string, rule v1alpha1.TagValidationRule) (bool, error) {
-	categoryID := ""
+// hasTagConstraints reports whether a rule constrains the tag names, cardinality or absence of tags in its category,
+// rather than only requiring the category
+func hasTagConstraints(rule v1alpha1.TagValidationRule) bool {
+	return rule.TagName != "" || rule.TagNamePattern != "" || len(rule.AllowedTagNames) > 0 || rule.Cardinality != "" || rule.Absent
+}
+
+func tagIsValid(tagsManager *tags.Manager, finder *find.Finder, datacenter string, rule v1alpha1.TagValidationRule) ([]string, error) {
+	category, ref, entityTags, err := lookupTags(tagsManager, finder, datacenter, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	// return early if no can't find the managedobject list
+	if ref == nil {
+		return []string{fmt.Sprintf("%s %s was not found", rule.EntityType, rule.EntityName)}, nil
+	}
+
+	return evaluateTags(rule, category, entityTags)
+}
+
+// lookupTags returns a rule's tag category, entity and the tags attached to the entity.
+// The category is nil if it does not exist and the entity is nil if it was not found.
+func lookupTags(tagsManager *tags.Manager, finder *find.Finder, datacenter string, rule v1alpha1.TagValidationRule) (*tags.Category, mo.Reference, []tags.Tag, error) {
+	var category *tags.Category
 	var inventoryPath string
 
 	cats, err := GetCategories(tagsManager)
 	if err != nil {
-		return false, err
+		return nil, nil, nil, err
 	}
-	for _, category := range cats {
-		switch category.Name {
-		case rule.Tag:
-			categoryID = category.ID
+	for i := range cats {
+		if cats[i].Name == rule.Tag {
+			category = &cats[i]
+			break
 		}
 	}
 
@@ -117,41 +161,106 @@ func tagIsValid(tagsManager *tags.Manager, finder *find.Finder, datacenter strin
 	case entity.VirtualMachine:
 		inventoryPath = rule.EntityName
 	default:
-		return false, fmt.Errorf("unsupported entity type: %s", rule.EntityType)
+		return nil, nil, nil, fmt.Errorf("unsupported entity type: %s", rule.EntityType)
 	}
 
 	// check if object has tag
 	list, err := finder.ManagedObjectList(context.TODO(), inventoryPath)
 	if err != nil {
-		return false, err
+		return nil, nil, nil, err
 	}
-
-	// return early if no can't find the managedobject list
 	if len(list) == 0 {
-		return false, nil
+		return category, nil, nil, nil
 	}
-	var refs []mo.Reference
-	refs = append(refs, list[0].Object.Reference())
-	attachedTags, err := GetAttachedTagsOnObjects(tagsManager, refs)
+	ref := list[0].Object.Reference()
+	attachedTags, err := GetAttachedTagsOnObjects(tagsManager, []mo.Reference{ref})
 	if err != nil {
-		return false, err
+		return nil, nil, nil, err
 	}
-	isEntityTagged := false
 
+	var entityTags []tags.Tag
 	for _, attachedTag := range attachedTags {
-		for _, tagName := range attachedTag.Tags {
-			if tagName.CategoryID == categoryID {
-				isEntityTagged = true
-				break
-			}
+		entityTags = append(entityTags, attachedTag.Tags...)
+	}
+
+	return category, ref, entityTags, nil
+}
+
+// evaluateTags compares the tags attached to a rule's entity against the rule's expectations.
+func evaluateTags(rule v1alpha1.TagValidationRule, category *tags.Category, entityTags []tags.Tag) ([]string, error) {
+	failures := make([]string, 0)
+
+	if category == nil {
+		if rule.Absent {
+			return failures, nil
 		}
+		failures = append(failures, fmt.Sprintf("tag category %s was not found", rule.Tag))
+		return failures, errEntityTagsNotFound
 	}
 
-	if isEntityTagged {
-		return true, nil
+	if rule.Cardinality != "" && !strings.EqualFold(category.Cardinality, rule.Cardinality) {
+		failures = append(failures, fmt.Sprintf(
+			"tag category %s has cardinality %s, expected %s", category.Name, category.Cardinality, rule.Cardinality,
+		))
+	}
+
+	var pattern *regexp.Regexp
+	if rule.TagNamePattern != "" {
+		var err error
+		pattern, err = regexp.Compile(rule.TagNamePattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag name pattern %s: %w", rule.TagNamePattern, err)
+		}
 	}
 
-	return false, errors.New("entity tags don't exist")
+	inCategory := make([]string, 0)
+	matching := make([]string, 0)
+	for _, t := range entityTags {
+		if t.CategoryID != category.ID {
+			continue
+		}
+		inCategory = append(inCategory, t.Name)
+		if tagNameMatches(rule, pattern, t.Name) {
+			matching = append(matching, t.Name)
+		}
+	}
+
+	if rule.Absent {
+		for _, name := range matching {
+			failures = append(failures, fmt.Sprintf(
+				"tag %s in category %s must not be attached to %s %s", name, rule.Tag, rule.EntityType, rule.EntityName,
+			))
+		}
+		return failures, nil
+	}
+
+	if len(inCategory) == 0 {
+		failures = append(failures, fmt.Sprintf(
+			"no tag in category %s is attached to %s %s", rule.Tag, rule.EntityType, rule.EntityName,
+		))
+		return failures, errEntityTagsNotFound
+	}
+	if len(matching) == 0 {
+		failures = append(failures, fmt.Sprintf(
+			"%s %s has tag(s) %v in category %s, none of which match the expected tag",
+			rule.EntityType, rule.EntityName, inCategory, rule.Tag,
+		))
+	}
+
+	return failures, nil
+}
+
+func tagNameMatches(rule v1alpha1.TagValidationRule, pattern *regexp.Regexp, name string) bool {
+	if rule.TagName != "" && name != rule.TagName {
+		return false
+	}
+	if pattern != nil && !pattern.MatchString(name) {
+		return false
+	}
+	if len(rule.AllowedTagNames) > 0 && !slices.Contains(rule.AllowedTagNames, name) {
+		return false
+	}
+	return true
 }
 
 func getAttachedTagsOnObjects(tagsManager *tags.Manager, refs []mo.Reference) ([]tags.AttachedTags, error) {
@@ -0,0 +1,226 @@
+package tags
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/vapi/tags"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter/entity"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+func TestEvaluateTags(t *testing.T) {
+	zoneCategory := &tags.Category{ID: "zone-id", Name: "k8s-zone", Cardinality: "SINGLE"}
+	entityTags := []tags.Tag{
+		{Name: "zone-a", CategoryID: "zone-id"},
+		{Name: "team-x", CategoryID: "owner-id"},
+	}
+
+	tests := []struct {
+		name             string
+		rule             v1alpha1.TagValidationRule
+		category         *tags.Category
+		entityTags       []tags.Tag
+		expectedFailures []string
+		expectedErr      error
+	}{
+		{
+			name:             "Any tag in category",
+			rule:             newRule(func(r *v1alpha1.TagValidationRule) {}),
+			category:         zoneCategory,
+			entityTags:       entityTags,
+			expectedFailures: []string{},
+		},
+		{
+			name: "Expected tag name attached",
+			rule: newRule(func(r *v1alpha1.TagValidationRule) {
+				r.TagName = "zone-a"
+				r.Cardinality = "SINGLE"
+			}),
+			category:         zoneCategory,
+			entityTags:       entityTags,
+			expectedFailures: []string{},
+		},
+		{
+			name: "Wrong tag name attached",
+			rule: newRule(func(r *v1alpha1.TagValidationRule) {
+				r.TagName = "zone-b"
+			}),
+			category:   zoneCategory,
+			entityTags: entityTags,
+			expectedFailures: []string{
+				"Cluster DC0_C0 has tag(s) [zone-a] in category k8s-zone, none of which match the expected tag",
+			},
+		},
+		{
+			name: "Tag name pattern and allowed list",
+			rule: newRule(func(r *v1alpha1.TagValidationRule) {
+				r.TagNamePattern = "^zone-[a-c]$"
+				r.AllowedTagNames = []string{"zone-a", "zone-b"}
+			}),
+			category:         zoneCategory,
+			entityTags:       entityTags,
+			expectedFailures: []string{},
+		},
+		{
+			name: "Cardinality mismatch",
+			rule: newRule(func(r *v1alpha1.TagValidationRule) {
+				r.Cardinality = "MULTIPLE"
+			}),
+			category:   zoneCategory,
+			entityTags: entityTags,
+			expectedFailures: []string{
+				"tag category k8s-zone has cardinality SINGLE, expected MULTIPLE",
+			},
+		},
+		{
+			name:       "No tag in category",
+			rule:       newRule(func(r *v1alpha1.TagValidationRule) {}),
+			category:   zoneCategory,
+			entityTags: []tags.Tag{{Name: "team-x", CategoryID: "owner-id"}},
+			expectedFailures: []string{
+				"no tag in category k8s-zone is attached to Cluster DC0_C0",
+			},
+			expectedErr: errEntityTagsNotFound,
+		},
+		{
+			name:             "Missing category",
+			rule:             newRule(func(r *v1alpha1.TagValidationRule) {}),
+			category:         nil,
+			entityTags:       entityTags,
+			expectedFailures: []string{"tag category k8s-zone was not found"},
+			expectedErr:      errEntityTagsNotFound,
+		},
+		{
+			name: "Absent tag attached",
+			rule: newRule(func(r *v1alpha1.TagValidationRule) {
+				r.TagName = "zone-a"
+				r.Absent = true
+			}),
+			category:   zoneCategory,
+			entityTags: entityTags,
+			expectedFailures: []string{
+				"tag zone-a in category k8s-zone must not be attached to Cluster DC0_C0",
+			},
+		},
+		{
+			name: "Absent tag not attached",
+			rule: newRule(func(r *v1alpha1.TagValidationRule) {
+				r.TagName = "zone-b"
+				r.Absent = true
+			}),
+			category:         zoneCategory,
+			entityTags:       entityTags,
+			expectedFailures: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures, err := evaluateTags(tt.rule, tt.category, tt.entityTags)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedFailures, failures)
+		})
+	}
+}
+
+func TestReconcileTagRulesRemediation(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8476, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	driver, err := vsphere.NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	finder, _, err := driver.GetFinderWithDatacenter(context.Background(), vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tagsManager := tags.NewManager(driver.RestClient)
+	s := NewValidationService(logr.Logger{})
+
+	rule := newRule(func(r *v1alpha1.TagValidationRule) {
+		r.Tag = "remediation-zone"
+		r.TagName = "zone-a"
+		r.Remediation = v1alpha1.Remediation{Enabled: true, DryRun: true}
+	})
+
+	// dry run plans the changes without applying them
+	vr, err := s.ReconcileTagRules(tagsManager, finder, driver, rule)
+	assert.ErrorIs(t, err, errEntityTagsNotFound)
+	assert.Equal(t, corev1.ConditionFalse, vr.Condition.Status)
+	assert.Equal(t, []string{
+		"remediation planned (dry run): create tag category remediation-zone with cardinality SINGLE",
+		"remediation planned (dry run): create tag zone-a in category remediation-zone",
+		"remediation planned (dry run): attach tag zone-a in category remediation-zone to Cluster DC0_C0",
+	}, vr.Condition.Details)
+
+	// remediation applies the changes and re-validates the rule
+	rule.Remediation.DryRun = false
+	vr, err = s.ReconcileTagRules(tagsManager, finder, driver, rule)
+	assert.NoError(t, err)
+	assert.Equal(t, corev1.ConditionTrue, vr.Condition.Status)
+	assert.Equal(t, []string{
+		"remediation applied: create tag category remediation-zone with cardinality SINGLE",
+		"remediation applied: create tag zone-a in category remediation-zone",
+		"remediation applied: attach tag zone-a in category remediation-zone to Cluster DC0_C0",
+	}, vr.Condition.Details)
+
+	// a single cardinality category's conflicting tag is replaced
+	rule.TagName = "zone-b"
+	vr, err = s.ReconcileTagRules(tagsManager, finder, driver, rule)
+	assert.NoError(t, err)
+	assert.Equal(t, corev1.ConditionTrue, vr.Condition.Status)
+	assert.Equal(t, []string{
+		"remediation applied: create tag zone-b in category remediation-zone",
+		"remediation applied: detach tag zone-a in category remediation-zone from Cluster DC0_C0",
+		"remediation applied: attach tag zone-b in category remediation-zone to Cluster DC0_C0",
+	}, vr.Condition.Details)
+}
+
+func TestBuildValidationResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     v1alpha1.TagValidationRule
+		expected string
+	}{
+		{
+			name:     "category rule keeps its original name",
+			rule:     newRule(func(*v1alpha1.TagValidationRule) {}),
+			expected: "validation-vsphere-tags-cluster-k8s-zone",
+		},
+		{
+			name:     "tag name rule is named after the rule",
+			rule:     newRule(func(r *v1alpha1.TagValidationRule) { r.TagName = "zone-a" }),
+			expected: "validation-vsphere-tags-cluster-zone-tag",
+		},
+		{
+			name:     "absent rule is named after the rule",
+			rule:     newRule(func(r *v1alpha1.TagValidationRule) { r.Absent = true }),
+			expected: "validation-vsphere-tags-cluster-zone-tag",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, buildValidationResult(tt.rule).Condition.ValidationRule)
+		})
+	}
+}
+
+func newRule(mutate func(r *v1alpha1.TagValidationRule)) v1alpha1.TagValidationRule {
+	rule := v1alpha1.TagValidationRule{
+		RuleName:   "Cluster zone tag",
+		EntityType: entity.Cluster.String(),
+		EntityName: "DC0_C0",
+		Tag:        "k8s-zone",
+	}
+	mutate(&rule)
+	return rule
+}
@@ -0,0 +1,209 @@
+// Package topology handles Kubernetes region/zone topology validation rule reconciliation.
+package topology
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	vapiconstants "github.com/validator-labs/validator/pkg/constants"
+	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter/entity"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+// ValidationService is a service that validates topology rules
+type ValidationService struct {
+	log    logr.Logger
+	driver *vsphere.VCenterDriver
+}
+
+// NewValidationService creates a new ValidationService
+func NewValidationService(log logr.Logger, driver *vsphere.VCenterDriver) *ValidationService {
+	return &ValidationService{
+		log:    log,
+		driver: driver,
+	}
+}
+
+func buildValidationResult(rule v1alpha1.TopologyValidationRule) *types.ValidationRuleResult {
+	state := vapi.ValidationSucceeded
+	validationType := constants.ValidationTypeTopology
+
+	validationRule := fmt.Sprintf("%s-%s-%s", vapiconstants.ValidationRulePrefix, validationType, rule.Name())
+
+	latestCondition := vapi.DefaultValidationCondition()
+	latestCondition.Message = "Region and zone topology is valid"
+	latestCondition.ValidationRule = util.Sanitize(validationRule)
+	latestCondition.ValidationType = validationType
+
+	return &types.ValidationRuleResult{Condition: &latestCondition, State: &state}
+}
+
+// ReconcileTopologyRule reconciles a topology rule
+func (s *ValidationService) ReconcileTopologyRule(rule v1alpha1.TopologyValidationRule) (*types.ValidationRuleResult, error) {
+	vr := buildValidationResult(rule)
+
+	regionCategory := rule.RegionCategory
+	if regionCategory == "" {
+		regionCategory = vsphere.K8sDatacenterTagCategory
+	}
+	zoneCategory := rule.ZoneCategory
+	if zoneCategory == "" {
+		zoneCategory = vsphere.K8sComputeClusterTagCategory
+	}
+	zoneEntityType := entity.Cluster
+	if rule.ZoneEntityType != "" {
+		e, ok := entity.Map[rule.ZoneEntityType]
+		if !ok || (e != entity.Cluster && e != entity.Host) {
+			return vr, fmt.Errorf("unsupported zone entity type: %s", rule.ZoneEntityType)
+		}
+		zoneEntityType = e
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	topology, err := s.driver.GetTopology(ctx, rule.Datacenters, regionCategory, zoneCategory, zoneEntityType)
+	if err != nil {
+		return vr, err
+	}
+
+	failures := validateTopology(topology, zoneEntityType, rule.Network)
+	vr.Condition.Details = append(vr.Condition.Details, summarize(topology, zoneEntityType)...)
+
+	if len(failures) > 0 {
+		vr.State = util.Ptr(vapi.ValidationFailed)
+		vr.Condition.Failures = failures
+		vr.Condition.Message = "One or more topology requirements were not satisfied"
+		vr.Condition.Status = corev1.ConditionFalse
+	}
+
+	return vr, nil
+}
+
+type zone struct {
+	regions    map[string]bool
+	entities   []string
+	datastores map[string]int
+	networks   map[string]int
+}
+
+// validateTopology ensures that each datacenter has exactly one region tag, each zone entity has exactly one zone
+// tag, each zone belongs to a single region, and that each zone has a shared datastore and the required network.
+func validateTopology(topology []vcenter.TopologyDatacenter, zoneEntityType entity.Entity, network string) []string {
+	failures := make([]string, 0)
+	zones := make(map[string]*zone)
+
+	for _, dc := range topology {
+		if len(dc.Regions) != 1 {
+			failures = append(failures, fmt.Sprintf(
+				"Datacenter %s has %d region tags %v, expected exactly one", dc.Name, len(dc.Regions), dc.Regions,
+			))
+		}
+		for _, e := range dc.Entities {
+			if len(e.Zones) != 1 {
+				failures = append(failures, fmt.Sprintf(
+					"%s %s has %d zone tags %v, expected exactly one", zoneEntityType, e.Name, len(e.Zones), e.Zones,
+				))
+				continue
+			}
+			z, ok := zones[e.Zones[0]]
+			if !ok {
+				z = &zone{regions: map[string]bool{}, datastores: map[string]int{}, networks: map[string]int{}}
+				zones[e.Zones[0]] = z
+			}
+			for _, r := range dc.Regions {
+				z.regions[r] = true
+			}
+			z.entities = append(z.entities, e.Name)
+			for _, ds := range e.SharedDatastores {
+				z.datastores[ds]++
+			}
+			for _, n := range e.Networks {
+				z.networks[n]++
+			}
+		}
+	}
+
+	names := make([]string, 0, len(zones))
+	for name := range zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		z := zones[name]
+		if len(z.regions) > 1 {
+			failures = append(failures, fmt.Sprintf("Zone %s spans multiple regions %v", name, sortedKeys(z.regions)))
+		}
+		if zoneEntityType == entity.Cluster && len(z.entities) > 1 {
+			failures = append(failures, fmt.Sprintf("Zone %s is attached to multiple clusters %v", name, z.entities))
+		}
+		if !availableToAll(z.datastores, len(z.entities)) {
+			failures = append(failures, fmt.Sprintf("Zone %s has no datastore shared by all of %v", name, z.entities))
+		}
+		if network != "" && z.networks[network] != len(z.entities) {
+			failures = append(failures, fmt.Sprintf("Network %s is not available to all of %v in zone %s", network, z.entities, name))
+		}
+	}
+
+	return failures
+}
+
+// summarize renders the discovered region -> zone -> entity mapping.
+func summarize(topology []vcenter.TopologyDatacenter, zoneEntityType entity.Entity) []string {
+	details := make([]string, 0)
+	for _, dc := range topology {
+		byZone := make(map[string][]string)
+		for _, e := range dc.Entities {
+			for _, z := range e.Zones {
+				byZone[z] = append(byZone[z], e.Name)
+			}
+		}
+		zones := make([]string, 0, len(byZone))
+		for z := range byZone {
+			zones = append(zones, z)
+		}
+		sort.Strings(zones)
+
+		region := strings.Join(dc.Regions, ",")
+		if len(zones) == 0 {
+			details = append(details, fmt.Sprintf("Region %s (datacenter %s): no zones", region, dc.Name))
+		}
+		for _, z := range zones {
+			details = append(details, fmt.Sprintf(
+				"Region %s (datacenter %s) -> zone %s -> %s %v", region, dc.Name, z, zoneEntityType, byZone[z],
+			))
+		}
+	}
+	return details
+}
+
+func availableToAll(counts map[string]int, n int) bool {
+	for _, c := range counts {
+		if c == n {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,122 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter/entity"
+)
+
+func TestValidateTopology(t *testing.T) {
+	tests := []struct {
+		name             string
+		topology         []vcenter.TopologyDatacenter
+		zoneEntityType   entity.Entity
+		network          string
+		expectedFailures []string
+	}{
+		{
+			name: "Valid cluster topology",
+			topology: []vcenter.TopologyDatacenter{
+				{
+					Name:    "DC0",
+					Regions: []string{"us-east"},
+					Entities: []vcenter.TopologyEntity{
+						{Name: "C0", Zones: []string{"zone-a"}, SharedDatastores: []string{"ds0"}, Networks: []string{"VM Network"}},
+						{Name: "C1", Zones: []string{"zone-b"}, SharedDatastores: []string{"ds1"}, Networks: []string{"VM Network"}},
+					},
+				},
+			},
+			zoneEntityType:   entity.Cluster,
+			network:          "VM Network",
+			expectedFailures: []string{},
+		},
+		{
+			name: "Missing and duplicate tags",
+			topology: []vcenter.TopologyDatacenter{
+				{
+					Name:    "DC0",
+					Regions: []string{},
+					Entities: []vcenter.TopologyEntity{
+						{Name: "C0", Zones: []string{"zone-a", "zone-b"}, SharedDatastores: []string{"ds0"}},
+						{Name: "C1", Zones: []string{"zone-c"}, SharedDatastores: []string{"ds1"}},
+						{Name: "C2", Zones: []string{"zone-c"}, SharedDatastores: []string{"ds2"}},
+					},
+				},
+			},
+			zoneEntityType: entity.Cluster,
+			expectedFailures: []string{
+				"Datacenter DC0 has 0 region tags [], expected exactly one",
+				"Cluster C0 has 2 zone tags [zone-a zone-b], expected exactly one",
+				"Zone zone-c is attached to multiple clusters [C1 C2]",
+				"Zone zone-c has no datastore shared by all of [C1 C2]",
+			},
+		},
+		{
+			name: "Zone spanning regions",
+			topology: []vcenter.TopologyDatacenter{
+				{
+					Name:     "DC0",
+					Regions:  []string{"us-east"},
+					Entities: []vcenter.TopologyEntity{{Name: "H0", Zones: []string{"zone-a"}, SharedDatastores: []string{"ds0"}}},
+				},
+				{
+					Name:     "DC1",
+					Regions:  []string{"us-west"},
+					Entities: []vcenter.TopologyEntity{{Name: "H1", Zones: []string{"zone-a"}, SharedDatastores: []string{"ds0"}}},
+				},
+			},
+			zoneEntityType: entity.Host,
+			expectedFailures: []string{
+				"Zone zone-a spans multiple regions [us-east us-west]",
+			},
+		},
+		{
+			name: "Host zone missing network",
+			topology: []vcenter.TopologyDatacenter{
+				{
+					Name:    "DC0",
+					Regions: []string{"us-east"},
+					Entities: []vcenter.TopologyEntity{
+						{Name: "H0", Zones: []string{"zone-a"}, SharedDatastores: []string{"ds0"}, Networks: []string{"k8s"}},
+						{Name: "H1", Zones: []string{"zone-a"}, SharedDatastores: []string{"ds0"}, Networks: []string{"VM Network"}},
+					},
+				},
+			},
+			zoneEntityType: entity.Host,
+			network:        "k8s",
+			expectedFailures: []string{
+				"Network k8s is not available to all of [H0 H1] in zone zone-a",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures := validateTopology(tt.topology, tt.zoneEntityType, tt.network)
+			assert.Equal(t, tt.expectedFailures, failures)
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	topology := []vcenter.TopologyDatacenter{
+		{
+			Name:    "DC0",
+			Regions: []string{"us-east"},
+			Entities: []vcenter.TopologyEntity{
+				{Name: "C0", Zones: []string{"zone-a"}},
+				{Name: "C1", Zones: []string{"zone-b"}},
+			},
+		},
+		{Name: "DC1", Regions: []string{"us-west"}},
+	}
+	expected := []string{
+		"Region us-east (datacenter DC0) -> zone zone-a -> Cluster [C0]",
+		"Region us-east (datacenter DC0) -> zone zone-b -> Cluster [C1]",
+		"Region us-west (datacenter DC1): no zones",
+	}
+	assert.Equal(t, expected, summarize(topology, entity.Cluster))
+}
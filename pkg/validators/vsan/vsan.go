@@ -0,0 +1,182 @@
+// Package vsan handles vSAN cluster validation rule reconciliation.
+package vsan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/find"
+	corev1 "k8s.io/api/core/v1"
+
+	vapi "github.com/validator-labs/validator/api/v1alpha1"
+	vapiconstants "github.com/validator-labs/validator/pkg/constants"
+	"github.com/validator-labs/validator/pkg/types"
+	"github.com/validator-labs/validator/pkg/util"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/v1alpha1"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vsphere"
+)
+
+const (
+	healthRed    = "red"
+	healthYellow = "yellow"
+
+	bytesPerGB = 1024 * 1024 * 1024
+)
+
+// ValidationService is a service that validates vSAN rules
+type ValidationService struct {
+	log        logr.Logger
+	driver     *vsphere.VCenterDriver
+	datacenter string
+}
+
+// NewValidationService creates a new ValidationService
+func NewValidationService(log logr.Logger, driver *vsphere.VCenterDriver, datacenter string) *ValidationService {
+	return &ValidationService{
+		log:        log,
+		driver:     driver,
+		datacenter: datacenter,
+	}
+}
+
+func buildValidationResult(rule v1alpha1.VSANValidationRule) *types.ValidationRuleResult {
+	state := vapi.ValidationSucceeded
+	validationType := constants.ValidationTypeVSAN
+
+	validationRule := fmt.Sprintf("%s-%s-%s", vapiconstants.ValidationRulePrefix, validationType, rule.Name())
+
+	latestCondition := vapi.DefaultValidationCondition()
+	latestCondition.Message = fmt.Sprintf("vSAN is enabled and healthy on cluster %s", rule.ClusterName)
+	latestCondition.ValidationRule = util.Sanitize(validationRule)
+	latestCondition.ValidationType = validationType
+
+	return &types.ValidationRuleResult{Condition: &latestCondition, State: &state}
+}
+
+// ReconcileVSANRule reconciles a vSAN rule
+func (s *ValidationService) ReconcileVSANRule(rule v1alpha1.VSANValidationRule, finder *find.Finder) (*types.ValidationRuleResult, error) {
+	vr := buildValidationResult(rule)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	failures := make([]string, 0)
+
+	info, err := s.driver.GetVSANClusterInfo(ctx, finder, s.datacenter, rule.ClusterName)
+	if err != nil {
+		var notFoundErr *find.NotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return vr, err
+		}
+		failures = append(failures, fmt.Sprintf("cluster %s not found", rule.ClusterName))
+	} else if !info.Enabled {
+		failures = append(failures, fmt.Sprintf("vSAN is not enabled on cluster %s", rule.ClusterName))
+	} else {
+		failures = append(failures, validateHealth(info, rule.IgnoreHealthWarnings)...)
+		failures = append(failures, validateHostDisks(info)...)
+
+		if rule.MinUsableFreeCapacityGB > 0 {
+			policyName := rule.StoragePolicyName
+			if policyName == "" {
+				policyName = vcenter.VSANDefaultStoragePolicyName
+			}
+			policy, err := s.driver.GetVSANStoragePolicy(ctx, policyName)
+			if err != nil {
+				return vr, err
+			}
+			failures = append(failures, validateCapacity(info, policy, rule.MinUsableFreeCapacityGB)...)
+		}
+	}
+
+	if len(failures) > 0 {
+		vr.State = util.Ptr(vapi.ValidationFailed)
+		vr.Condition.Failures = failures
+		vr.Condition.Message = fmt.Sprintf("vSAN is not enabled, not healthy, or has insufficient capacity on cluster %s", rule.ClusterName)
+		vr.Condition.Status = corev1.ConditionFalse
+	}
+
+	return vr, nil
+}
+
+// validateHealth reports the overall vSAN health and each failing health test
+func validateHealth(info *vcenter.VSANClusterInfo, ignoreWarnings bool) []string {
+	failures := make([]string, 0)
+
+	if failing(info.OverallHealth, ignoreWarnings) {
+		failures = append(failures, fmt.Sprintf("vSAN overall health is %s: %s", info.OverallHealth, info.OverallHealthDescription))
+	}
+	for _, t := range info.HealthTests {
+		if failing(t.Health, ignoreWarnings) {
+			failures = append(failures, fmt.Sprintf("vSAN health test %s / %s is %s", t.Group, t.Name, t.Health))
+		}
+	}
+
+	return failures
+}
+
+func failing(health string, ignoreWarnings bool) bool {
+	return health == healthRed || (health == healthYellow && !ignoreWarnings)
+}
+
+// validateHostDisks ensures that every host in the cluster contributes disks to vSAN
+func validateHostDisks(info *vcenter.VSANClusterInfo) []string {
+	failures := make([]string, 0)
+
+	hosts := make([]string, 0, len(info.HostDisksInUse))
+	for host := range info.HostDisksInUse {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		if info.HostDisksInUse[host] == 0 {
+			failures = append(failures, fmt.Sprintf("Host: %s: does not contribute any disks to vSAN", host))
+		}
+	}
+
+	return failures
+}
+
+// validateCapacity ensures that the free capacity, after accounting for the storage policy's overhead,
+// is at least minGB
+func validateCapacity(info *vcenter.VSANClusterInfo, policy *vcenter.VSANStoragePolicy, minGB int) []string {
+	rawGB := float64(info.FreeCapacityBytes) / bytesPerGB
+	usableGB := rawGB / overhead(policy)
+
+	if usableGB < float64(minGB) {
+		return []string{fmt.Sprintf(
+			"usable free capacity %.1f GB (%.1f GB raw, storage policy %s with FTT=%d %s) is less than %d GB",
+			usableGB, rawGB, policy.Name, policy.FailuresToTolerate, method(policy), minGB,
+		)}
+	}
+	return []string{}
+}
+
+// overhead returns the ratio of raw capacity consumed to usable capacity for a storage policy:
+// FTT+1 for RAID-1 mirroring, 4/3 for RAID-5 (FTT=1) and 3/2 for RAID-6 (FTT=2) erasure coding
+func overhead(policy *vcenter.VSANStoragePolicy) float64 {
+	if policy.ErasureCoding {
+		switch policy.FailuresToTolerate {
+		case 0:
+			return 1
+		case 1:
+			return 4.0 / 3.0
+		default:
+			return 1.5
+		}
+	}
+	return float64(policy.FailuresToTolerate + 1)
+}
+
+func method(policy *vcenter.VSANStoragePolicy) string {
+	if policy.ErasureCoding {
+		return "erasure coding"
+	}
+	return "mirroring"
+}
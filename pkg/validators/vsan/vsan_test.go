@@ -0,0 +1,105 @@
+package vsan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+func TestValidateHealth(t *testing.T) {
+	info := &vcenter.VSANClusterInfo{
+		OverallHealth:            "yellow",
+		OverallHealthDescription: "Warnings were found",
+		HealthTests: []vcenter.VSANHealthTest{
+			{Group: "Network", Name: "vSAN cluster partition", Health: "green"},
+			{Group: "Physical disk", Name: "Operation health", Health: "red"},
+			{Group: "Cluster", Name: "Time is synchronized across hosts and VC", Health: "yellow"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		ignoreWarnings bool
+		expected       []string
+	}{
+		{
+			name:           "Warnings ignored",
+			ignoreWarnings: true,
+			expected: []string{
+				"vSAN health test Physical disk / Operation health is red",
+			},
+		},
+		{
+			name: "Warnings not ignored",
+			expected: []string{
+				"vSAN overall health is yellow: Warnings were found",
+				"vSAN health test Physical disk / Operation health is red",
+				"vSAN health test Cluster / Time is synchronized across hosts and VC is yellow",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validateHealth(info, tt.ignoreWarnings))
+		})
+	}
+}
+
+func TestValidateHostDisks(t *testing.T) {
+	info := &vcenter.VSANClusterInfo{
+		HostDisksInUse: map[string]int{"esx03": 0, "esx01": 4, "esx02": 0},
+	}
+	assert.Equal(t, []string{
+		"Host: esx02: does not contribute any disks to vSAN",
+		"Host: esx03: does not contribute any disks to vSAN",
+	}, validateHostDisks(info))
+}
+
+func TestValidateCapacity(t *testing.T) {
+	info := &vcenter.VSANClusterInfo{FreeCapacityBytes: 1200 * bytesPerGB}
+
+	tests := []struct {
+		name     string
+		policy   *vcenter.VSANStoragePolicy
+		minGB    int
+		expected []string
+	}{
+		{
+			name:     "Pass with RAID-1 FTT=1",
+			policy:   &vcenter.VSANStoragePolicy{Name: "raid1", FailuresToTolerate: 1},
+			minGB:    600,
+			expected: []string{},
+		},
+		{
+			name:   "Fail with RAID-1 FTT=1",
+			policy: &vcenter.VSANStoragePolicy{Name: "raid1", FailuresToTolerate: 1},
+			minGB:  601,
+			expected: []string{
+				"usable free capacity 600.0 GB (1200.0 GB raw, storage policy raid1 with FTT=1 mirroring) is less than 601 GB",
+			},
+		},
+		{
+			name:     "Pass with RAID-5",
+			policy:   &vcenter.VSANStoragePolicy{Name: "raid5", FailuresToTolerate: 1, ErasureCoding: true},
+			minGB:    900,
+			expected: []string{},
+		},
+		{
+			name:   "Fail with RAID-6",
+			policy: &vcenter.VSANStoragePolicy{Name: "raid6", FailuresToTolerate: 2, ErasureCoding: true},
+			minGB:  900,
+			expected: []string{
+				"usable free capacity 800.0 GB (1200.0 GB raw, storage policy raid6 with FTT=2 erasure coding) is less than 900 GB",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validateCapacity(info, tt.policy, tt.minGB))
+		})
+	}
+}
@@ -13,8 +13,10 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	_ "github.com/vmware/govmomi/pbm/simulator" // Importing the simulator package to enable simulation of storage policies
 	"github.com/vmware/govmomi/simulator"
-	_ "github.com/vmware/govmomi/vapi/simulator" // Importing the simulator package to enable simulation of vCenter server
+	_ "github.com/vmware/govmomi/vapi/namespace/simulator" // Importing the simulator package to enable simulation of Workload Management
+	_ "github.com/vmware/govmomi/vapi/simulator"           // Importing the simulator package to enable simulation of vCenter server
 
 	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
 )
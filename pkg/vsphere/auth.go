@@ -0,0 +1,169 @@
+package vsphere
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/sts"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+const (
+	// tokenExchangePath is the vCenter 8 identity federation token exchange endpoint
+	tokenExchangePath = "/api/vcenter/tokenservice/token-exchange"
+
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	tokenTypeSAML2         = "urn:ietf:params:oauth:token-type:saml2"
+	tokenTypePrefix        = "urn:ietf:params:oauth:token-type:"
+	defaultFederatedType   = "access_token"
+)
+
+// tokenExchangeSpec is the request body for the vCenter token exchange endpoint
+type tokenExchangeSpec struct {
+	GrantType          string `json:"grant_type"`
+	SubjectToken       string `json:"subject_token"`
+	SubjectTokenType   string `json:"subject_token_type"`
+	RequestedTokenType string `json:"requested_token_type"`
+}
+
+// tokenExchangeInfo is the response body of the vCenter token exchange endpoint
+type tokenExchangeInfo struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+}
+
+// login authenticates a govmomi client using the account's authentication method
+func login(ctx context.Context, c *govmomi.Client, account vcenter.Account) error {
+	signer, err := newSigner(ctx, c.Client, account)
+	if err != nil {
+		return err
+	}
+	if signer == nil {
+		return c.Login(ctx, account.Userinfo())
+	}
+
+	header := soap.Header{Security: signer}
+	if err := c.SessionManager.LoginByToken(c.WithHeader(ctx, header)); err != nil {
+		return fmt.Errorf("failed to login to vCenter using %s authentication: %w", account.AuthMethod(), err)
+	}
+	return nil
+}
+
+// loginRest authenticates a REST client using the account's authentication method
+func loginRest(ctx context.Context, rc *rest.Client, vimClient *vim25.Client, account vcenter.Account) error {
+	signer, err := newSigner(ctx, vimClient, account)
+	if err != nil {
+		return err
+	}
+	if signer == nil {
+		return rc.Login(ctx, account.Userinfo())
+	}
+
+	if err := rc.LoginByToken(rc.WithSigner(ctx, signer)); err != nil {
+		return fmt.Errorf("failed to login to vCenter REST API using %s authentication: %w", account.AuthMethod(), err)
+	}
+	return nil
+}
+
+// newSigner returns a SAML token signer for the account, or nil if the account uses password authentication
+func newSigner(ctx context.Context, c *vim25.Client, account vcenter.Account) (*sts.Signer, error) {
+	switch account.AuthMethod() {
+	case vcenter.AuthMethodToken:
+		signer := &sts.Signer{Token: account.Token}
+		if account.Certificate != "" {
+			cert, err := accountCertificate(account)
+			if err != nil {
+				return nil, err
+			}
+			signer.Certificate = cert
+		}
+		return signer, nil
+	case vcenter.AuthMethodCertificate:
+		cert, err := accountCertificate(account)
+		if err != nil {
+			return nil, err
+		}
+		stsClient, err := sts.NewClient(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create STS client: %w", err)
+		}
+		signer, err := stsClient.Issue(ctx, sts.TokenRequest{Certificate: cert, Delegatable: true})
+		if err != nil {
+			return nil, fmt.Errorf("failed to issue holder-of-key token: %w", err)
+		}
+		return signer, nil
+	case vcenter.AuthMethodTokenExchange:
+		token, err := exchangeToken(ctx, c, account)
+		if err != nil {
+			return nil, err
+		}
+		return &sts.Signer{Token: token}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// accountCertificate parses the account's PEM-encoded certificate and private key
+func accountCertificate(account vcenter.Account) (*tls.Certificate, error) {
+	if account.PrivateKey == "" {
+		return nil, fmt.Errorf("private key is required for certificate authentication")
+	}
+	cert, err := tls.X509KeyPair([]byte(account.Certificate), []byte(account.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	// the STS only signs requests with RSA keys
+	if _, ok := cert.PrivateKey.(*rsa.PrivateKey); !ok {
+		return nil, fmt.Errorf("certificate authentication requires an RSA private key")
+	}
+	return &cert, nil
+}
+
+// exchangeToken exchanges the account's federated token for a SAML token using vCenter identity federation
+func exchangeToken(ctx context.Context, c *vim25.Client, account vcenter.Account) (string, error) {
+	tokenType := account.FederatedTokenType
+	if tokenType == "" {
+		tokenType = defaultFederatedType
+	}
+	spec := tokenExchangeSpec{
+		GrantType:          tokenExchangeGrantType,
+		SubjectToken:       account.FederatedToken,
+		SubjectTokenType:   tokenTypePrefix + tokenType,
+		RequestedTokenType: tokenTypeSAML2,
+	}
+
+	rc := rest.NewClient(c)
+	req := rc.Resource(tokenExchangePath).Request(http.MethodPost, spec)
+
+	var info tokenExchangeInfo
+	if err := rc.Do(ctx, req, &info); err != nil {
+		return "", fmt.Errorf("failed to exchange federated token: %w", err)
+	}
+	return decodeSAMLToken(info.AccessToken)
+}
+
+// decodeSAMLToken decodes a base64url-encoded SAML token, as returned by the token exchange endpoint
+func decodeSAMLToken(token string) (string, error) {
+	if token == "" {
+		return "", fmt.Errorf("token exchange returned an empty token")
+	}
+	if strings.HasPrefix(strings.TrimSpace(token), "<") {
+		return token, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(token, "="))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode SAML token: %w", err)
+	}
+	return string(decoded), nil
+}
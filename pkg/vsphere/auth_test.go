@@ -0,0 +1,248 @@
+package vsphere
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	_ "github.com/vmware/govmomi/lookup/simulator" // Importing the lookup service simulator package to locate the STS
+	_ "github.com/vmware/govmomi/sts/simulator"    // Importing the STS simulator package to enable token issuance
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+)
+
+func TestDecodeSAMLToken(t *testing.T) {
+	assertion := `<saml2:Assertion xmlns:saml2="urn:oasis:names:tc:SAML:2.0:assertion"></saml2:Assertion>`
+
+	tests := []struct {
+		name        string
+		token       string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "Base64url encoded without padding",
+			token:    base64.RawURLEncoding.EncodeToString([]byte(assertion)),
+			expected: assertion,
+		},
+		{
+			name:     "Base64url encoded with padding",
+			token:    base64.URLEncoding.EncodeToString([]byte(assertion)),
+			expected: assertion,
+		},
+		{
+			name:     "Raw XML",
+			token:    assertion,
+			expected: assertion,
+		},
+		{
+			name:        "Empty",
+			token:       "",
+			expectError: true,
+		},
+		{
+			name:        "Invalid encoding",
+			token:       "not base64!",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := decodeSAMLToken(tt.token)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, token)
+		})
+	}
+}
+
+func TestAuthMethod(t *testing.T) {
+	tests := []struct {
+		name     string
+		account  vcenter.Account
+		expected vcenter.AuthMethod
+	}{
+		{
+			name:     "Password",
+			account:  vcenter.Account{Username: "admin", Password: "password"},
+			expected: vcenter.AuthMethodPassword,
+		},
+		{
+			name:     "Token",
+			account:  vcenter.Account{Token: "<saml2:Assertion/>"},
+			expected: vcenter.AuthMethodToken,
+		},
+		{
+			name:     "Holder-of-key token",
+			account:  vcenter.Account{Token: "<saml2:Assertion/>", Certificate: "cert", PrivateKey: "key"},
+			expected: vcenter.AuthMethodToken,
+		},
+		{
+			name:     "Certificate",
+			account:  vcenter.Account{Certificate: "cert", PrivateKey: "key"},
+			expected: vcenter.AuthMethodCertificate,
+		},
+		{
+			name:     "Token exchange",
+			account:  vcenter.Account{FederatedToken: "jwt", Username: "admin"},
+			expected: vcenter.AuthMethodTokenExchange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.account.AuthMethod())
+		})
+	}
+}
+
+func TestAccountCertificate(t *testing.T) {
+	_, err := accountCertificate(vcenter.Account{Certificate: "cert"})
+	assert.ErrorContains(t, err, "private key is required")
+
+	_, err = accountCertificate(vcenter.Account{Certificate: "cert", PrivateKey: "key"})
+	assert.ErrorContains(t, err, "failed to parse certificate")
+}
+
+func TestLogin(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8479, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	cert, key := newTestCertificate(t, false)
+	_, otherKey := newTestCertificate(t, false)
+	ecCert, ecKey := newTestCertificate(t, true)
+	token := `<saml2:Assertion xmlns:saml2="urn:oasis:names:tc:SAML:2.0:assertion">` +
+		`<saml2:Subject><saml2:NameID>solution@vsphere.local</saml2:NameID></saml2:Subject></saml2:Assertion>`
+
+	tests := []struct {
+		name          string
+		account       vcenter.Account
+		expectedUser  string
+		expectedError string
+	}{
+		{
+			name:         "Token",
+			account:      vcenter.Account{Host: vcSim.Account.Host, Insecure: true, Token: token},
+			expectedUser: "solution@vsphere.local",
+		},
+		{
+			name:         "Holder-of-key token",
+			account:      vcenter.Account{Host: vcSim.Account.Host, Insecure: true, Token: token, Certificate: cert, PrivateKey: key},
+			expectedUser: "solution@vsphere.local",
+		},
+		{
+			// the STS simulator issues a token for Administrator regardless of the certificate
+			name:         "Certificate",
+			account:      vcenter.Account{Host: vcSim.Account.Host, Insecure: true, Certificate: cert, PrivateKey: key},
+			expectedUser: "Administrator@VSPHERE.LOCAL",
+		},
+		{
+			name:          "Certificate without private key",
+			account:       vcenter.Account{Host: vcSim.Account.Host, Insecure: true, Certificate: cert},
+			expectedError: "private key is required for certificate authentication",
+		},
+		{
+			name:          "Invalid certificate",
+			account:       vcenter.Account{Host: vcSim.Account.Host, Insecure: true, Certificate: "cert", PrivateKey: key},
+			expectedError: "failed to parse certificate",
+		},
+		{
+			name:          "Mismatched private key",
+			account:       vcenter.Account{Host: vcSim.Account.Host, Insecure: true, Certificate: cert, PrivateKey: otherKey},
+			expectedError: "failed to parse certificate",
+		},
+		{
+			name:          "Non-RSA private key",
+			account:       vcenter.Account{Host: vcSim.Account.Host, Insecure: true, Certificate: ecCert, PrivateKey: ecKey},
+			expectedError: "certificate authentication requires an RSA private key",
+		},
+		{
+			name:          "Holder-of-key token with invalid private key",
+			account:       vcenter.Account{Host: vcSim.Account.Host, Insecure: true, Token: token, Certificate: cert, PrivateKey: "key"},
+			expectedError: "failed to parse certificate",
+		},
+		{
+			name:          "Token without subject",
+			account:       vcenter.Account{Host: vcSim.Account.Host, Insecure: true, Token: `<saml2:Assertion xmlns:saml2="urn:oasis:names:tc:SAML:2.0:assertion"/>`},
+			expectedError: "failed to login to vCenter using token authentication",
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewSessionManager(0, logr.Discard())
+			defer m.Close(ctx)
+
+			s, err := m.GetOrCreate(ctx, tt.account, true)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Equal(t, 0, m.Len())
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			us, err := s.GovmomiClient.SessionManager.UserSession(ctx)
+			if assert.NoError(t, err) && assert.NotNil(t, us) {
+				assert.Equal(t, tt.expectedUser, us.UserName)
+			}
+			rs, err := s.RestClient.Session(ctx)
+			assert.NoError(t, err)
+			assert.NotNil(t, rs, "expected REST client to be logged in")
+		})
+	}
+}
+
+// newTestCertificate returns a PEM-encoded self-signed certificate and its private key, which is an ECDSA key if ec
+// is true and an RSA key otherwise
+func newTestCertificate(t *testing.T, ec bool) (string, string) {
+	var key, public any
+	if ec {
+		k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, public = k, &k.PublicKey
+	} else {
+		k, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, public = k, &k.PublicKey
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "solution"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, public, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	return string(cert), string(privateKey)
+}
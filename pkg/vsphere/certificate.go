@@ -0,0 +1,113 @@
+package vsphere
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+const defaultHTTPSPort = "443"
+
+// GetVCenterCertificates returns the certificate chain presented by the vCenter endpoint, leaf certificate first
+func (v *VCenterDriver) GetVCenterCertificates(ctx context.Context) ([]vcenter.Certificate, error) {
+	u := v.Client.URL()
+	port := u.Port()
+	if port == "" {
+		port = defaultHTTPSPort
+	}
+
+	// The chain is only inspected, never trusted, so it is retrieved without verification
+	dialer := tls.Dialer{
+		Config: &tls.Config{
+			ServerName:         u.Hostname(),
+			InsecureSkipVerify: true, // nolint:gosec
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to connect to vCenter %s", u.Host))
+	}
+	defer conn.Close()
+
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return nil, fmt.Errorf("failed to get TLS connection state of vCenter %s", u.Host)
+	}
+
+	peers := tlsConn.ConnectionState().PeerCertificates
+	certs := make([]vcenter.Certificate, 0, len(peers))
+	for _, c := range peers {
+		certs = append(certs, toCertificate(c))
+	}
+
+	return certs, nil
+}
+
+// GetHostCertificates returns the certificates of the given hosts, or of all hosts in the cluster if no hosts are given.
+// The names of hosts that could not be found are returned separately, as are the reasons the certificates of any other
+// hosts are unavailable, keyed by host name.
+func (v *VCenterDriver) GetHostCertificates(ctx context.Context, finder *find.Finder, datacenter, clusterName string, hosts []string) ([]vcenter.HostCertificate, []string, map[string]string, error) {
+	refs, notFound, err := v.getHostReferences(ctx, finder, datacenter, clusterName, hosts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(refs) == 0 {
+		return nil, notFound, nil, nil
+	}
+
+	var hss []mo.HostSystem
+	pc := property.DefaultCollector(v.Client.Client)
+	if err := pc.Retrieve(ctx, refs, []string{"name", "configManager.certificateManager"}, &hss); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to retrieve host certificate managers")
+	}
+
+	certs := make([]vcenter.HostCertificate, 0, len(hss))
+	unavailable := make(map[string]string)
+	for _, hs := range hss {
+		if hs.ConfigManager.CertificateManager == nil {
+			unavailable[hs.Name] = "no certificate manager"
+			continue
+		}
+		m := object.NewHostCertificateManager(v.Client.Client, *hs.ConfigManager.CertificateManager, hs.Reference())
+		info, err := m.CertificateInfo(ctx)
+		if err != nil {
+			unavailable[hs.Name] = err.Error()
+			continue
+		}
+
+		cert := vcenter.Certificate{
+			Subject: info.Subject,
+			Issuer:  info.Issuer,
+		}
+		if info.NotBefore != nil {
+			cert.NotBefore = *info.NotBefore
+		}
+		if info.NotAfter != nil {
+			cert.NotAfter = *info.NotAfter
+		}
+		certs = append(certs, vcenter.HostCertificate{Host: hs.Name, Certificate: cert})
+	}
+	sort.Slice(certs, func(i, j int) bool { return certs[i].Host < certs[j].Host })
+
+	return certs, notFound, unavailable, nil
+}
+
+func toCertificate(c *x509.Certificate) vcenter.Certificate {
+	return vcenter.Certificate{
+		Subject:   c.Subject.String(),
+		Issuer:    c.Issuer.String(),
+		NotBefore: c.NotBefore,
+		NotAfter:  c.NotAfter,
+	}
+}
@@ -0,0 +1,64 @@
+package vsphere
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/simulator"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+)
+
+func TestGetCertificates(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8468, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notBefore := time.Unix(0, 0).UTC()
+	notAfter := time.Date(2084, time.January, 29, 16, 0, 0, 0, time.UTC)
+
+	vcCerts, err := driver.GetVCenterCertificates(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []vcenter.Certificate{
+		{Subject: "O=Acme Co", Issuer: "O=Acme Co", NotBefore: notBefore, NotAfter: notAfter},
+	}, vcCerts)
+
+	hostCerts, notFound, unavailable, err := driver.GetHostCertificates(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster, []string{"DC0_C0_H0", "missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, []vcenter.HostCertificate{
+		{
+			Host:        "DC0_C0_H0",
+			Certificate: vcenter.Certificate{Subject: "CN=,O=Acme Co", Issuer: "CN=,O=Acme Co", NotBefore: notBefore, NotAfter: notAfter},
+		},
+	}, hostCerts)
+	assert.Equal(t, []string{"missing"}, notFound)
+	assert.Empty(t, unavailable)
+
+	// a host without a certificate manager is reported rather than failing the lookup
+	host, err := driver.GetHost(ctx, finder, vcSim.Options.Datacenter, "DC0_C1", "DC0_C1_H0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	simulator.Map.Get(host.Reference()).(*simulator.HostSystem).ConfigManager.CertificateManager = nil
+
+	hostCerts, notFound, unavailable, err = driver.GetHostCertificates(ctx, finder, vcSim.Options.Datacenter, "DC0_C1", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, hostCerts)
+	assert.Empty(t, notFound)
+	assert.Equal(t, map[string]string{"DC0_C1_H0": "no certificate manager"}, unavailable)
+}
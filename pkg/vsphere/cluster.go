@@ -9,6 +9,10 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	"golang.org/x/exp/slices"
 
 	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
 )
@@ -16,6 +20,11 @@ import (
 // GetCluster returns the cluster if it exists
 func (v *VCenterDriver) GetCluster(ctx context.Context, finder *find.Finder, datacenter, clusterName string) (*object.ClusterComputeResource, error) {
 	path := fmt.Sprintf(vcenter.HostInventoryPath, datacenter, clusterName)
+	if obj, ok := v.cachedObject(path); ok {
+		if cluster, ok := obj.(*object.ClusterComputeResource); ok {
+			return cluster, nil
+		}
+	}
 	cluster, err := finder.ClusterComputeResource(ctx, path)
 	if err != nil {
 		return nil, err
@@ -23,6 +32,160 @@ func (v *VCenterDriver) GetCluster(ctx context.Context, finder *find.Finder, dat
 	return cluster, nil
 }
 
+// GetClusterResources returns the summaries of a cluster's datastores and hosts
+func (v *VCenterDriver) GetClusterResources(ctx context.Context, finder *find.Finder, datacenter, clusterName string) ([]mo.Datastore, []mo.HostSystem, error) {
+	cluster, err := v.GetCluster(ctx, finder, datacenter, clusterName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if datastores, hosts, ok := v.inventory.clusterResources(cluster.Reference()); ok {
+		return datastores, hosts, nil
+	}
+	pc := property.DefaultCollector(v.Client.Client)
+
+	var ccr mo.ClusterComputeResource
+	if err := pc.RetrieveOne(ctx, cluster.Reference(), []string{"datastore", "host"}, &ccr); err != nil {
+		return nil, nil, err
+	}
+
+	var datastores []mo.Datastore
+	if err := pc.Retrieve(ctx, ccr.Datastore, []string{"summary"}, &datastores); err != nil {
+		return nil, nil, err
+	}
+
+	var hosts []mo.HostSystem
+	if err := pc.Retrieve(ctx, ccr.Host, []string{"summary"}, &hosts); err != nil {
+		return nil, nil, err
+	}
+
+	return datastores, hosts, nil
+}
+
+// GetClusterDRSConfig returns a cluster's DRS configuration, its DRS rules, and the names of the VMs in the cluster
+func (v *VCenterDriver) GetClusterDRSConfig(ctx context.Context, finder *find.Finder, datacenter, clusterName string) (*vcenter.ClusterDRSConfig, error) {
+	cluster, err := v.GetCluster(ctx, finder, datacenter, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := cluster.Configuration(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to get configuration of cluster %s", clusterName))
+	}
+
+	var ccr mo.ClusterComputeResource
+	if err := cluster.Properties(ctx, cluster.Reference(), []string{"host"}, &ccr); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to get hosts of cluster %s", clusterName))
+	}
+	clusterVMRefs := make([]types.ManagedObjectReference, 0)
+	if len(ccr.Host) > 0 {
+		var hosts []mo.HostSystem
+		if err := v.Client.Retrieve(ctx, ccr.Host, []string{"vm"}, &hosts); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to get VMs of cluster %s", clusterName))
+		}
+		for _, h := range hosts {
+			clusterVMRefs = append(clusterVMRefs, h.Vm...)
+		}
+	}
+
+	vmGroups := make(map[string][]types.ManagedObjectReference)
+	for _, g := range cfg.Group {
+		if vmGroup, ok := g.(*types.ClusterVmGroup); ok {
+			vmGroups[vmGroup.Name] = vmGroup.Vm
+		}
+	}
+
+	// resolve the names of all VMs referenced by the cluster and its rules in a single round trip
+	refs := slices.Clone(clusterVMRefs)
+	for _, vms := range vmGroups {
+		refs = append(refs, vms...)
+	}
+	for _, r := range cfg.Rule {
+		switch rule := r.(type) {
+		case *types.ClusterAffinityRuleSpec:
+			refs = append(refs, rule.Vm...)
+		case *types.ClusterAntiAffinityRuleSpec:
+			refs = append(refs, rule.Vm...)
+		}
+	}
+	names, err := v.getVMNames(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	toNames := func(refs []types.ManagedObjectReference) []string {
+		vms := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			if name, ok := names[ref.Value]; ok {
+				vms = append(vms, name)
+			}
+		}
+		sort.Strings(vms)
+		return vms
+	}
+
+	drsConfig := &vcenter.ClusterDRSConfig{
+		DRSEnabled: cfg.DrsConfig.Enabled != nil && *cfg.DrsConfig.Enabled,
+		Rules:      make([]vcenter.DRSRule, 0, len(cfg.Rule)),
+		VMs:        toNames(clusterVMRefs),
+	}
+	for _, r := range cfg.Rule {
+		info := r.GetClusterRuleInfo()
+		rule := vcenter.DRSRule{
+			Name:      info.Name,
+			Enabled:   info.Enabled != nil && *info.Enabled,
+			Mandatory: info.Mandatory != nil && *info.Mandatory,
+		}
+		switch ri := r.(type) {
+		case *types.ClusterAffinityRuleSpec:
+			rule.Type = vcenter.DRSRuleTypeVMAffinity
+			rule.VMs = toNames(ri.Vm)
+		case *types.ClusterAntiAffinityRuleSpec:
+			rule.Type = vcenter.DRSRuleTypeVMAntiAffinity
+			rule.VMs = toNames(ri.Vm)
+		case *types.ClusterVmHostRuleInfo:
+			rule.Type = vcenter.DRSRuleTypeVMHost
+			rule.VMGroup = ri.VmGroupName
+			rule.HostGroup = ri.AffineHostGroupName
+			if rule.HostGroup == "" {
+				rule.HostGroup = ri.AntiAffineHostGroupName
+			}
+			rule.VMs = toNames(vmGroups[ri.VmGroupName])
+		default:
+			// other rule types, e.g., VM-VM dependency rules, are not supported
+			continue
+		}
+		drsConfig.Rules = append(drsConfig.Rules, rule)
+	}
+
+	return drsConfig, nil
+}
+
+// getVMNames returns a map of VM reference values to VM names
+func (v *VCenterDriver) getVMNames(ctx context.Context, refs []types.ManagedObjectReference) (map[string]string, error) {
+	names := make(map[string]string, len(refs))
+	if len(refs) == 0 {
+		return names, nil
+	}
+
+	unique := make([]types.ManagedObjectReference, 0, len(refs))
+	seen := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		if !seen[ref.Value] {
+			seen[ref.Value] = true
+			unique = append(unique, ref)
+		}
+	}
+
+	var vms []mo.VirtualMachine
+	if err := v.Client.Retrieve(ctx, unique, []string{"name"}, &vms); err != nil {
+		return nil, errors.Wrap(err, "failed to get VM names")
+	}
+	for _, vm := range vms {
+		names[vm.Reference().Value] = vm.Name
+	}
+	return names, nil
+}
+
 // GetClusters returns a sorted list of all vCenter clusters within a datacenter.
 func (v *VCenterDriver) GetClusters(ctx context.Context, datacenter string) ([]string, error) {
 	prefix, ccrs, err := v.getClusterComputeResources(ctx, datacenter)
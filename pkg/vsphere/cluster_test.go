@@ -0,0 +1,102 @@
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+)
+
+func TestGetClusterDRSConfig(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8466, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cluster, err := driver.GetCluster(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm1, err := driver.GetVM(ctx, finder, "DC0_C0_RP1_VM0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm2, err := driver.GetVM(ctx, finder, "DC0_C0_RP2_VM0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec := &types.ClusterConfigSpecEx{
+		DrsConfig: &types.ClusterDrsConfigInfo{Enabled: types.NewBool(true)},
+		GroupSpec: []types.ClusterGroupSpec{
+			{
+				ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationAdd},
+				Info: &types.ClusterVmGroup{
+					ClusterGroupInfo: types.ClusterGroupInfo{Name: "cp-vms"},
+					Vm:               []types.ManagedObjectReference{vm1.Reference()},
+				},
+			},
+		},
+		RulesSpec: []types.ClusterRuleSpec{
+			{
+				ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationAdd},
+				Info: &types.ClusterAntiAffinityRuleSpec{
+					ClusterRuleInfo: types.ClusterRuleInfo{Name: "cp-anti-affinity", Enabled: types.NewBool(true), Mandatory: types.NewBool(true)},
+					Vm:              []types.ManagedObjectReference{vm1.Reference(), vm2.Reference()},
+				},
+			},
+			{
+				ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationAdd},
+				Info: &types.ClusterVmHostRuleInfo{
+					ClusterRuleInfo:     types.ClusterRuleInfo{Name: "cp-on-hosts", Enabled: types.NewBool(false)},
+					VmGroupName:         "cp-vms",
+					AffineHostGroupName: "cp-hosts",
+				},
+			},
+		},
+	}
+	task, err := cluster.Reconfigure(ctx, spec, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := task.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := driver.GetClusterDRSConfig(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster)
+	assert.NoError(t, err)
+	assert.True(t, cfg.DRSEnabled)
+	assert.Equal(t, []vcenter.DRSRule{
+		{
+			Name:      "cp-anti-affinity",
+			Type:      vcenter.DRSRuleTypeVMAntiAffinity,
+			Enabled:   true,
+			Mandatory: true,
+			VMs:       []string{"DC0_C0_RP1_VM0", "DC0_C0_RP2_VM0"},
+		},
+		{
+			Name:      "cp-on-hosts",
+			Type:      vcenter.DRSRuleTypeVMHost,
+			VMs:       []string{"DC0_C0_RP1_VM0"},
+			VMGroup:   "cp-vms",
+			HostGroup: "cp-hosts",
+		},
+	}, cfg.Rules)
+	assert.Contains(t, cfg.VMs, "DC0_C0_RP1_VM0")
+	assert.Contains(t, cfg.VMs, "DC0_C0_RP2_VM0")
+}
@@ -2,6 +2,7 @@ package vsphere
 
 import (
 	"context"
+	"path"
 	"sort"
 	"strings"
 
@@ -12,6 +13,11 @@ import (
 
 // GetDatacenter returns a datacenter object if it exists
 func (v *VCenterDriver) GetDatacenter(ctx context.Context, finder *find.Finder, datacenter string) (*object.Datacenter, error) {
+	if obj, ok := v.cachedObject(path.Join("/", datacenter)); ok {
+		if dc, ok := obj.(*object.Datacenter); ok {
+			return dc, nil
+		}
+	}
 	dc, err := finder.Datacenter(ctx, datacenter)
 	if err != nil {
 		return nil, err
@@ -15,6 +15,13 @@ import (
 
 // GetDatastore returns a datastore object if it exists
 func (v *VCenterDriver) GetDatastore(ctx context.Context, finder *find.Finder, datastore string) (*object.Datastore, error) {
+	if p, ok := v.datacenterPath("datastore", datastore); ok {
+		if obj, ok := v.cachedObject(p); ok {
+			if ds, ok := obj.(*object.Datastore); ok {
+				return ds, nil
+			}
+		}
+	}
 	ds, err := finder.Datastore(ctx, datastore)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,319 @@
+package vsphere
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/event"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+)
+
+const (
+	// eventPageSize is the number of events retrieved from the event history collector at once.
+	eventPageSize = 100
+
+	// maxPendingEvents is the maximum number of events retained per owner until they are drained.
+	maxPendingEvents = 10
+)
+
+// hostValidationTypes are the validation types whose outcome depends on the state of ESXi hosts
+var hostValidationTypes = []string{
+	constants.ValidationTypeComputeResources, constants.ValidationTypeNTP, constants.ValidationTypeHostDNS,
+	constants.ValidationTypeHostNetwork, constants.ValidationTypeVSAN, constants.ValidationTypeCertificate,
+	constants.ValidationTypeLicense, constants.ValidationTypeNSXSegment,
+}
+
+// EventValidationTypes maps the vCenter event types that are tailed to the validation types of the rules they could affect.
+var EventValidationTypes = map[string][]string{
+	"PermissionAddedEvent":           {constants.ValidationTypePrivileges},
+	"PermissionRemovedEvent":         {constants.ValidationTypePrivileges},
+	"PermissionUpdatedEvent":         {constants.ValidationTypePrivileges},
+	"RoleUpdatedEvent":               {constants.ValidationTypePrivileges},
+	"RoleRemovedEvent":               {constants.ValidationTypePrivileges},
+	"HostConnectionLostEvent":        hostValidationTypes,
+	"HostDisconnectedEvent":          hostValidationTypes,
+	"HostConnectedEvent":             hostValidationTypes,
+	"HostRemovedEvent":               hostValidationTypes,
+	"EnteredMaintenanceModeEvent":    hostValidationTypes,
+	"ExitMaintenanceModeEvent":       hostValidationTypes,
+	"com.vmware.cis.tagging.attach":  {constants.ValidationTypeTag, constants.ValidationTypeTopology},
+	"com.vmware.cis.tagging.detach":  {constants.ValidationTypeTag, constants.ValidationTypeTopology},
+	"com.vmware.cis.tagging.delete":  {constants.ValidationTypeTag, constants.ValidationTypeTopology},
+	"com.vmware.cis.tagging.updated": {constants.ValidationTypeTag, constants.ValidationTypeTopology},
+}
+
+// RoleEventTypes are the tailed vCenter event types that describe a change to a role rather than to an entity.
+var RoleEventTypes = []string{"RoleUpdatedEvent", "RoleRemovedEvent"}
+
+// eventOwner is an EventCollector registration
+type eventOwner struct {
+	key             SessionKey
+	validationTypes []string
+}
+
+// EventCollector tails the vCenter event stream, one event history collector per vCenter session, for events of the
+// types in EventValidationTypes. Owners register for the validation types of their rules and are notified of every
+// event that could affect them. The events are retained until drained so that they can be attached to failures.
+type EventCollector struct {
+	mu            sync.Mutex
+	collectors    map[SessionKey]context.CancelFunc
+	owners        map[string]eventOwner
+	pending       map[string][]vcenter.Event
+	notify        func(owners []string)
+	sessions      *SessionManager
+	retryInterval time.Duration
+	log           logr.Logger
+}
+
+// NewEventCollector creates a new EventCollector. notify is called with the owners affected by new events.
+func NewEventCollector(notify func(owners []string), log logr.Logger) *EventCollector {
+	return newEventCollector(defaultSessionManager, DefaultWatchRetryInterval, notify, log)
+}
+
+func newEventCollector(sessions *SessionManager, retryInterval time.Duration, notify func(owners []string), log logr.Logger) *EventCollector {
+	return &EventCollector{
+		collectors:    make(map[SessionKey]context.CancelFunc),
+		owners:        make(map[string]eventOwner),
+		pending:       make(map[string][]vcenter.Event),
+		notify:        notify,
+		sessions:      sessions,
+		retryInterval: retryInterval,
+		log:           log,
+	}
+}
+
+// Watch registers owner for events that could affect rules of the given validation types, starting to tail the
+// event stream of the account's session if it is not tailed already. If owner was previously registered for a
+// different session, that registration is released.
+func (c *EventCollector) Watch(owner string, account vcenter.Account, validationTypes []string) error {
+	key, err := NewSessionKey(account)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prev, ok := c.owners[owner]
+	c.owners[owner] = eventOwner{key: key, validationTypes: validationTypes}
+	if ok && prev.key != key {
+		delete(c.pending, owner)
+		c.stopUnreferenced(prev.key)
+	}
+
+	if _, ok := c.collectors[key]; !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		c.collectors[key] = cancel
+		go c.run(ctx, key, account)
+	}
+	return nil
+}
+
+// Unwatch releases owner's registration and discards its pending events, stopping to tail the event stream
+// if no other owner is registered for it.
+func (c *EventCollector) Unwatch(owner string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	o, ok := c.owners[owner]
+	delete(c.owners, owner)
+	delete(c.pending, owner)
+	if ok {
+		c.stopUnreferenced(o.key)
+	}
+}
+
+// Drain returns and clears the events received for owner since it was last drained, oldest first.
+func (c *EventCollector) Drain(owner string) []vcenter.Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	events := c.pending[owner]
+	delete(c.pending, owner)
+	return events
+}
+
+// Close stops tailing all event streams, regardless of their owners.
+func (c *EventCollector) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, cancel := range c.collectors {
+		cancel()
+	}
+	c.collectors = make(map[SessionKey]context.CancelFunc)
+	c.owners = make(map[string]eventOwner)
+	c.pending = make(map[string][]vcenter.Event)
+}
+
+// stopUnreferenced stops tailing the event stream for the given key if no owner is registered for it.
+// The caller must hold c.mu.
+func (c *EventCollector) stopUnreferenced(key SessionKey) {
+	for _, o := range c.owners {
+		if o.key == key {
+			return
+		}
+	}
+	if cancel, ok := c.collectors[key]; ok {
+		c.log.V(1).Info("stopping unreferenced vCenter event collector", "host", key.Host, "username", key.Username)
+		cancel()
+		delete(c.collectors, key)
+	}
+}
+
+// dispatch records events for the owners whose rules they could affect and returns those owners
+func (c *EventCollector) dispatch(key SessionKey, events []vcenter.Event) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	owners := make([]string, 0)
+	for name, o := range c.owners {
+		if o.key != key {
+			continue
+		}
+		affected := false
+		for _, e := range events {
+			if affects(e, o.validationTypes) {
+				c.pending[name] = append(c.pending[name], e)
+				affected = true
+			}
+		}
+		if !affected {
+			continue
+		}
+		if n := len(c.pending[name]); n > maxPendingEvents {
+			c.pending[name] = c.pending[name][n-maxPendingEvents:]
+		}
+		owners = append(owners, name)
+	}
+	return owners
+}
+
+// run tails the event stream until ctx is canceled, resuming whenever it fails
+func (c *EventCollector) run(ctx context.Context, key SessionKey, account vcenter.Account) {
+	for {
+		err := c.tail(ctx, key, account)
+		if ctx.Err() != nil {
+			return
+		}
+		c.log.Error(err, "vCenter event collector failed", "host", key.Host, "retryInterval", c.retryInterval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.retryInterval):
+		}
+	}
+}
+
+// tail notifies the affected owners of every event created after tailing starts
+func (c *EventCollector) tail(ctx context.Context, key SessionKey, account vcenter.Account) error {
+	s, err := c.sessions.GetOrCreate(ctx, account, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to get vCenter session")
+	}
+	client := s.GovmomiClient.Client
+
+	// the collector's first page holds events that occurred before tailing started, which are skipped
+	since, err := methods.GetCurrentTime(ctx, client)
+	if err != nil {
+		return errors.Wrap(err, "failed to get vCenter time")
+	}
+
+	kinds := make([]string, 0, len(EventValidationTypes))
+	for k := range EventValidationTypes {
+		kinds = append(kinds, k)
+	}
+	slices.Sort(kinds)
+
+	c.log.V(1).Info("tailing vCenter events", "host", key.Host, "username", key.Username)
+
+	root := []types.ManagedObjectReference{client.ServiceContent.RootFolder}
+	return event.NewManager(client).Events(ctx, root, eventPageSize, true, false, func(_ types.ManagedObjectReference, page []types.BaseEvent) error {
+		events := make([]vcenter.Event, 0, len(page))
+		// pages are ordered newest first
+		for i := len(page) - 1; i >= 0; i-- {
+			e := toEvent(page[i])
+			if e.CreatedTime.Before(*since) {
+				continue
+			}
+			events = append(events, e)
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		owners := c.dispatch(key, events)
+		c.log.V(1).Info("received vCenter events", "host", key.Host, "events", len(events), "owners", owners)
+		if len(owners) > 0 {
+			c.notify(owners)
+		}
+		return nil
+	}, kinds...)
+}
+
+// affects reports whether an event could affect rules of any of the given validation types
+func affects(e vcenter.Event, validationTypes []string) bool {
+	for _, t := range EventValidationTypes[e.Type] {
+		if slices.Contains(validationTypes, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// toEvent converts a vCenter event
+func toEvent(be types.BaseEvent) vcenter.Event {
+	e := be.GetEvent()
+	ev := vcenter.Event{
+		Type:        eventType(be),
+		UserName:    e.UserName,
+		CreatedTime: e.CreatedTime,
+		Message:     e.FullFormattedMessage,
+	}
+
+	switch {
+	case e.Host != nil:
+		ev.Entity = e.Host.Name
+		if e.ComputeResource != nil {
+			ev.Cluster = e.ComputeResource.Name
+		}
+	case e.ComputeResource != nil:
+		ev.Entity = e.ComputeResource.Name
+	case e.Vm != nil:
+		ev.Entity = e.Vm.Name
+	case e.Ds != nil:
+		ev.Entity = e.Ds.Name
+	}
+	if pe, ok := be.(types.BasePermissionEvent); ok {
+		ev.Entity = pe.GetPermissionEvent().Entity.Name
+	}
+	if re, ok := be.(types.BaseRoleEvent); ok {
+		ev.Entity = re.GetRoleEvent().Role.Name
+	}
+	if ex, ok := be.(*types.EventEx); ok && ev.Entity == "" {
+		ev.Entity = ex.ObjectName
+	}
+
+	return ev
+}
+
+// eventType returns the type ID of an extended event, or otherwise the name of the event's type
+func eventType(be types.BaseEvent) string {
+	switch e := be.(type) {
+	case *types.EventEx:
+		return e.EventTypeId
+	case *types.ExtendedEvent:
+		return e.EventTypeId
+	}
+	return reflect.TypeOf(be).Elem().Name()
+}
@@ -0,0 +1,126 @@
+package vsphere
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/event"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+)
+
+func TestEventCollector(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8475, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	sessions := NewSessionManager(0, logr.Discard())
+	defer sessions.Close(context.Background())
+
+	notified := make(chan []string, 10)
+	c := newEventCollector(sessions, time.Second, func(owners []string) { notified <- owners }, logr.Discard())
+	defer c.Close()
+
+	if err := c.Watch("default/privileges", vcSim.Account, []string{constants.ValidationTypePrivileges}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Watch("default/ntp", vcSim.Account, []string{constants.ValidationTypeNTP}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cluster, err := driver.GetCluster(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// allow the collector to start tailing
+	time.Sleep(time.Second)
+
+	m := event.NewManager(driver.Client.Client)
+	e := &types.PermissionRemovedEvent{
+		PermissionEvent: types.PermissionEvent{
+			AuthorizationEvent: types.AuthorizationEvent{
+				Event: types.Event{
+					FullFormattedMessage: "Permission rule removed for admin on DC0_C0",
+				},
+			},
+			Entity:    types.ManagedEntityEventArgument{Entity: cluster.Reference(), EntityEventArgument: types.EntityEventArgument{Name: "DC0_C0"}},
+			Principal: "admin",
+		},
+	}
+	if err := m.PostEvent(ctx, e); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case owners := <-notified:
+		assert.Equal(t, []string{"default/privileges"}, owners)
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected notification after removing permission")
+	}
+
+	events := c.Drain("default/privileges")
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "PermissionRemovedEvent", events[0].Type)
+		assert.Equal(t, vcSim.Account.Username, events[0].UserName)
+		assert.Equal(t, "DC0_C0", events[0].Entity)
+	}
+	assert.Empty(t, c.Drain("default/privileges"))
+	assert.Empty(t, c.Drain("default/ntp"))
+
+	c.Unwatch("default/privileges")
+	c.Unwatch("default/ntp")
+	assert.Empty(t, c.collectors)
+}
+
+func TestToEvent(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		event    types.BaseEvent
+		expected vcenter.Event
+	}{
+		{
+			name: "host event records the host's cluster",
+			event: &types.HostConnectionLostEvent{
+				HostEvent: types.HostEvent{
+					Event: types.Event{
+						CreatedTime:     created,
+						Host:            &types.HostEventArgument{EntityEventArgument: types.EntityEventArgument{Name: "DC0_C0_H0"}},
+						ComputeResource: &types.ComputeResourceEventArgument{EntityEventArgument: types.EntityEventArgument{Name: "DC0_C0"}},
+					},
+				},
+			},
+			expected: vcenter.Event{Type: "HostConnectionLostEvent", CreatedTime: created, Entity: "DC0_C0_H0", Cluster: "DC0_C0"},
+		},
+		{
+			name: "extended event records its object",
+			event: &types.EventEx{
+				Event:       types.Event{CreatedTime: created, UserName: "VSPHERE.LOCAL\\bob"},
+				EventTypeId: "com.vmware.cis.tagging.attach",
+				ObjectName:  "DC0_C0",
+			},
+			expected: vcenter.Event{Type: "com.vmware.cis.tagging.attach", UserName: "VSPHERE.LOCAL\\bob", CreatedTime: created, Entity: "DC0_C0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, toEvent(tt.event))
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 
 	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
 )
@@ -51,6 +53,49 @@ func (v *VCenterDriver) GetFolder(ctx context.Context, datacenter, name string)
 	return folder, nil
 }
 
+// GetFolderSummary returns the child types and the names of the VMs directly within a folder.
+// Relative paths are resolved beneath the datacenter's root folder of the given type (vm, host, datastore or network).
+func (v *VCenterDriver) GetFolderSummary(ctx context.Context, finder *find.Finder, datacenter, folderType, path string) (*vcenter.FolderSummary, error) {
+	inventoryPath := path
+	if !strings.HasPrefix(path, "/") {
+		inventoryPath = strings.TrimSuffix(fmt.Sprintf(vcenter.FolderInventoryPath, datacenter, folderType, strings.Trim(path, "/")), "/")
+	}
+
+	folder, err := finder.Folder(ctx, inventoryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var mf mo.Folder
+	if err := folder.Properties(ctx, folder.Reference(), []string{"childType", "childEntity"}, &mf); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to get properties of folder %s", inventoryPath))
+	}
+
+	vmRefs := make([]types.ManagedObjectReference, 0)
+	for _, ref := range mf.ChildEntity {
+		if ref.Type == "VirtualMachine" {
+			vmRefs = append(vmRefs, ref)
+		}
+	}
+	vms := make([]string, 0, len(vmRefs))
+	if len(vmRefs) > 0 {
+		var mvms []mo.VirtualMachine
+		if err := v.Client.Retrieve(ctx, vmRefs, []string{"name"}, &mvms); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to get VMs in folder %s", inventoryPath))
+		}
+		for _, vm := range mvms {
+			vms = append(vms, vm.Name)
+		}
+		sort.Strings(vms)
+	}
+
+	return &vcenter.FolderSummary{
+		InventoryPath: inventoryPath,
+		ChildTypes:    mf.ChildType,
+		VMs:           vms,
+	}, nil
+}
+
 // GetVMFolders returns a list of vCenter VM folders
 func (v *VCenterDriver) GetVMFolders(ctx context.Context, datacenter string) ([]string, error) {
 	finder, dc, err := v.GetFinderWithDatacenter(ctx, datacenter)
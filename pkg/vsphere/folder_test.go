@@ -0,0 +1,50 @@
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/find"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+)
+
+func TestGetFolderSummary(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8464, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := driver.GetFolderSummary(ctx, finder, vcSim.Options.Datacenter, "vm", "")
+	assert.NoError(t, err)
+	assert.Equal(t, &vcenter.FolderSummary{
+		InventoryPath: "/DC0/vm",
+		ChildTypes:    []string{"VirtualMachine", "VirtualApp", "Folder"},
+		VMs:           []string{"DC0_C0_RP1_VM0", "DC0_C0_RP2_VM0", "DC0_C1_RP1_VM0", "DC0_C1_RP2_VM0", "DC0_H0_VM0"},
+	}, summary)
+
+	summary, err = driver.GetFolderSummary(ctx, finder, vcSim.Options.Datacenter, "vm", "/DC0/host")
+	assert.NoError(t, err)
+	assert.Equal(t, &vcenter.FolderSummary{
+		InventoryPath: "/DC0/host",
+		ChildTypes:    []string{"ComputeResource", "Folder"},
+		VMs:           []string{},
+	}, summary)
+
+	_, err = driver.GetFolderSummary(ctx, finder, vcSim.Options.Datacenter, "vm", "missing")
+	var notFoundErr *find.NotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
@@ -3,6 +3,9 @@ package vsphere
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/vmware/govmomi/find"
@@ -10,6 +13,7 @@ import (
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/property"
 	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
 	"golang.org/x/exp/slices"
@@ -26,6 +30,11 @@ func (v *VCenterDriver) GetHost(ctx context.Context, finder *find.Finder, datace
 		path = fmt.Sprintf(vcenter.HostInventoryPath, datacenter, hostName)
 	}
 
+	if obj, ok := v.cachedObject(path); ok {
+		if host, ok := obj.(*object.HostSystem); ok {
+			return host, nil
+		}
+	}
 	host, err := finder.HostSystem(ctx, path)
 	if err != nil {
 		return nil, err
@@ -35,14 +44,25 @@ func (v *VCenterDriver) GetHost(ctx context.Context, finder *find.Finder, datace
 
 // GetHostSystems returns vCenter host systems
 func (v *VCenterDriver) GetHostSystems(ctx context.Context, datacenter, cluster string) ([]vcenter.HostSystem, error) {
-	finder, _, err := v.GetFinderWithDatacenter(ctx, datacenter)
-	if err != nil {
-		return nil, err
-	}
-
 	path := fmt.Sprintf(vcenter.HostInventoryPath, datacenter, cluster)
 	if cluster == "" {
 		path = fmt.Sprintf(vcenter.HostInventoryGlob, datacenter)
+	} else if obj, ok := v.cachedObject(path); ok {
+		if _, hosts, ok := v.inventory.clusterResources(obj.Reference()); ok && len(hosts) > 0 {
+			hostSystems := make([]vcenter.HostSystem, 0, len(hosts))
+			for _, hs := range hosts {
+				hostSystems = append(hostSystems, vcenter.HostSystem{
+					Name:      hs.Name,
+					Reference: hs.Reference().String(),
+				})
+			}
+			return hostSystems, nil
+		}
+	}
+
+	finder, _, err := v.GetFinderWithDatacenter(ctx, datacenter)
+	if err != nil {
+		return nil, err
 	}
 
 	hss, err := finder.HostSystemList(ctx, path)
@@ -113,86 +133,313 @@ func getHostSystem(hostNameObj *types.ManagedObjectReference, hostSystems []mo.H
 	return nil
 }
 
-// ValidateHostNTPSettings validates the NTP settings for the hosts
-func (v *VCenterDriver) ValidateHostNTPSettings(ctx context.Context, finder *find.Finder, datacenter, clusterName string, hosts []string) (bool, []string, error) {
-	var failures []string
+// NTPOptions defines the expected time synchronization configuration for a set of hosts
+type NTPOptions struct {
+	// Protocol is the expected time synchronization protocol, either ntp or ptp. Defaults to ntp.
+	Protocol string
 
-	hostsDateInfo := make([]vcenter.HostDateInfo, 0, len(hosts))
-	for _, host := range hosts {
-		hostObj, err := v.GetHost(ctx, finder, datacenter, clusterName, host)
+	// ExpectedServers is the list of NTP servers that each host is expected to be configured with
+	ExpectedServers []string
+
+	// ServerMatch controls how each host's NTP servers are compared to ExpectedServers, either exact or subset. Defaults to exact.
+	ServerMatch string
+
+	// MaxClockSkew is the maximum allowed difference between each host's clock and vCenter's clock,
+	// and between the clocks of any two hosts. If zero, clock skew is not validated.
+	MaxClockSkew time.Duration
+}
+
+const (
+	// TimeProtocolNTP is the Network Time Protocol
+	TimeProtocolNTP = string(types.HostDateTimeInfoProtocolNtp)
+
+	// TimeProtocolPTP is the Precision Time Protocol
+	TimeProtocolPTP = string(types.HostDateTimeInfoProtocolPtp)
+
+	// NTPServerMatchExact requires each host to be configured with exactly the expected NTP servers
+	NTPServerMatchExact = "exact"
+
+	// NTPServerMatchSubset requires each host to only be configured with NTP servers from the expected NTP servers
+	NTPServerMatchSubset = "subset"
+)
+
+// ValidateHostNTPSettings validates the time synchronization settings for the hosts.
+// If no hosts are specified, all hosts in the cluster are validated. Problems are collected for every host
+// and reported as one failure per host; an error is only returned if vCenter could not be queried.
+func (v *VCenterDriver) ValidateHostNTPSettings(ctx context.Context, finder *find.Finder, datacenter, clusterName string, hosts []string, opts NTPOptions) (bool, []string, error) {
+	if len(hosts) == 0 {
+		hostSystems, err := v.GetHostSystems(ctx, datacenter, clusterName)
 		if err != nil {
 			return false, nil, err
 		}
+		for _, hs := range hostSystems {
+			hosts = append(hosts, hs.Name)
+		}
+	}
+
+	protocol := opts.Protocol
+	if protocol == "" {
+		protocol = TimeProtocolNTP
+	}
+	serviceKey := "ntpd"
+	if protocol == TimeProtocolPTP {
+		serviceKey = "ptpd"
+	}
+	label := strings.ToUpper(protocol)
+
+	// vCenter's clock is the reference for clock skew
+	vcTime, err := methods.GetCurrentTime(ctx, v.Client.Client)
+	if err != nil {
+		return false, nil, err
+	}
+	vcQueried := time.Now()
 
-		s, err := hostObj.ConfigManager().DateTimeSystem(ctx)
+	problems := make(map[string][]string, len(hosts))
+	hostsDateInfo := make([]vcenter.HostDateInfo, 0, len(hosts))
+	for _, host := range hosts {
+		res, err := v.getHostDateInfo(ctx, finder, datacenter, clusterName, host, serviceKey)
 		if err != nil {
+			var notFound *find.NotFoundError
+			if errors.As(err, &notFound) {
+				problems[host] = append(problems[host], "not found")
+				continue
+			}
 			return false, nil, err
 		}
+		if res.Service == nil {
+			problems[host] = append(problems[host], fmt.Sprintf("no %s service operating on it", label))
+			continue
+		}
+		if res.Current != nil && vcTime != nil {
+			res.Skew = res.Current.Sub(vcTime.Add(time.Since(vcQueried)))
+		}
+		hostsDateInfo = append(hostsDateInfo, *res)
+	}
 
-		var hs mo.HostDateTimeSystem
-		if err = s.Properties(ctx, s.Reference(), nil, &hs); err != nil {
-			return false, nil, err
+	for _, dateInfo := range hostsDateInfo {
+		host := dateInfo.HostName
+		problems[host] = append(problems[host], validateHostTimeProtocol(dateInfo, protocol)...)
+
+		if dateInfo.ClientStatus != "Enabled" {
+			problems[host] = append(problems[host], fmt.Sprintf("%s client status is disabled or unknown", label))
 		}
 
-		ss, err := hostObj.ConfigManager().ServiceSystem(ctx)
-		if err != nil {
-			return false, nil, err
+		if dateInfo.ServiceStatus != "Running" {
+			problems[host] = append(problems[host], fmt.Sprintf("%s service status is stopped or unknown", label))
 		}
 
-		services, err := ss.Service(ctx)
-		if err != nil {
-			return false, nil, err
+		if protocol == TimeProtocolNTP && len(opts.ExpectedServers) > 0 {
+			if err := validateExpectedNTPServers(dateInfo, opts.ExpectedServers, opts.ServerMatch); err != nil {
+				problems[host] = append(problems[host], err.Error())
+			}
 		}
+	}
 
-		res := &vcenter.HostDateInfo{HostDateTimeInfo: hs.DateTimeInfo}
+	if protocol == TimeProtocolNTP && validateHostNTPServers(hostsDateInfo) != nil {
+		for host, problem := range mismatchedNTPServers(hostsDateInfo) {
+			problems[host] = append(problems[host], problem)
+		}
+	}
 
-		for i, service := range services {
-			if service.Key == "ntpd" {
-				res.Service = &services[i]
-				break
-			}
+	if opts.MaxClockSkew > 0 {
+		for host, skewProblems := range validateClockSkew(hostsDateInfo, opts.MaxClockSkew) {
+			problems[host] = append(problems[host], skewProblems...)
 		}
+	}
 
-		if res.Service == nil {
-			failures = append(failures, fmt.Sprintf("Host: %s has no NTP service operating on it", host))
-			return false, failures, fmt.Errorf("host: %s has no NTP service operating on it", host)
+	failures := make([]string, 0)
+	for _, host := range hosts {
+		if len(problems[host]) > 0 {
+			failures = append(failures, fmt.Sprintf("Host: %s: %s", host, strings.Join(problems[host], "; ")))
 		}
+	}
+
+	return len(failures) == 0, failures, nil
+}
 
-		res.Current, err = s.Query(ctx)
+// GetHostNTPServers returns the NTP servers that each host in a cluster is configured with, keyed by host name.
+// Hosts without an NTP service are omitted.
+func (v *VCenterDriver) GetHostNTPServers(ctx context.Context, finder *find.Finder, datacenter, clusterName string) (map[string][]string, error) {
+	hostSystems, err := v.GetHostSystems(ctx, datacenter, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	servers := make(map[string][]string, len(hostSystems))
+	for _, hs := range hostSystems {
+		res, err := v.getHostDateInfo(ctx, finder, datacenter, clusterName, hs.Name, "ntpd")
 		if err != nil {
-			return false, nil, err
+			return nil, err
+		}
+		if res.Service == nil {
+			continue
 		}
+		servers[hs.Name] = res.NTPServers
+	}
+	return servers, nil
+}
 
-		res.ClientStatus = service.Policy(*res.Service)
-		res.ServiceStatus = service.Status(*res.Service)
-		res.HostName = host
-		res.NTPServers = res.Servers()
+// getHostDateInfo retrieves the date and time information and time service for a host.
+// Service is nil if the host has no service with the given key.
+func (v *VCenterDriver) getHostDateInfo(ctx context.Context, finder *find.Finder, datacenter, clusterName, host, serviceKey string) (*vcenter.HostDateInfo, error) {
+	hostObj, err := v.GetHost(ctx, finder, datacenter, clusterName, host)
+	if err != nil {
+		return nil, err
+	}
 
-		hostsDateInfo = append(hostsDateInfo, *res)
+	s, err := hostObj.ConfigManager().DateTimeSystem(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var hs mo.HostDateTimeSystem
+	if err = s.Properties(ctx, s.Reference(), nil, &hs); err != nil {
+		return nil, err
+	}
+
+	ss, err := hostObj.ConfigManager().ServiceSystem(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := ss.Service(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &vcenter.HostDateInfo{HostDateTimeInfo: hs.DateTimeInfo, HostName: host}
+
+	for i, service := range services {
+		if service.Key == serviceKey {
+			res.Service = &services[i]
+			break
+		}
 	}
+	if res.Service == nil {
+		return res, nil
+	}
+
+	res.Current, err = s.Query(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res.ClientStatus = service.Policy(*res.Service)
+	res.ServiceStatus = service.Status(*res.Service)
+	res.NTPServers = res.Servers()
+
+	return res, nil
+}
+
+// validateHostTimeProtocol ensures that a host uses the expected time synchronization protocol
+func validateHostTimeProtocol(dateInfo vcenter.HostDateInfo, protocol string) []string {
+	var problems []string
+
+	if protocol == TimeProtocolPTP && dateInfo.PtpConfig == nil {
+		problems = append(problems, "PTP is not configured; PTP requires ESXi 7.0 Update 3 or later")
+	}
+
+	// hosts prior to ESXi 7.0 Update 3 don't report a protocol and only support NTP
+	configured := dateInfo.SystemClockProtocol
+	if configured == "" {
+		configured = TimeProtocolNTP
+	}
+	if configured != protocol {
+		problems = append(problems, fmt.Sprintf("uses %s for time synchronization, expected %s", configured, protocol))
+	}
+
+	if dateInfo.ServiceSync != nil && !*dateInfo.ServiceSync {
+		problems = append(problems, fmt.Sprintf("not synchronized with its %s time source", strings.ToUpper(protocol)))
+	}
+
+	return problems
+}
 
+// validateExpectedNTPServers compares a host's NTP servers to the expected NTP servers
+func validateExpectedNTPServers(dateInfo vcenter.HostDateInfo, expected []string, match string) error {
+	switch match {
+	case NTPServerMatchSubset:
+		if len(dateInfo.NTPServers) == 0 {
+			return fmt.Errorf("no NTP servers configured, expected a subset of %v", expected)
+		}
+		for _, server := range dateInfo.NTPServers {
+			if !slices.Contains(expected, server) {
+				return fmt.Errorf("unexpected NTP server %s, expected a subset of %v", server, expected)
+			}
+		}
+	default:
+		actual := slices.Clone(dateInfo.NTPServers)
+		want := slices.Clone(expected)
+		slices.Sort(actual)
+		slices.Sort(want)
+		if !slices.Equal(actual, want) {
+			return fmt.Errorf("NTP servers %v, expected %v", dateInfo.NTPServers, expected)
+		}
+	}
+	return nil
+}
+
+// mismatchedNTPServers returns the hosts that aren't configured with the NTP server used by the most hosts
+func mismatchedNTPServers(hostsDateInfo []vcenter.HostDateInfo) map[string]string {
+	counts := make(map[string]int)
 	for _, dateInfo := range hostsDateInfo {
-		if dateInfo.ClientStatus != "Enabled" {
-			failureMsg := fmt.Sprintf("NTP client status is disabled or unknown for host: %s", dateInfo.HostName)
-			failures = append(failures, failureMsg)
+		for _, server := range dateInfo.NTPServers {
+			counts[server]++
 		}
+	}
 
-		if dateInfo.ServiceStatus != "Running" {
-			failureMsg := fmt.Sprintf("NTP service status is stopped or unknown for host: %s", dateInfo.HostName)
-			failures = append(failures, failureMsg)
+	var common string
+	for server, count := range counts {
+		if count > counts[common] || count == counts[common] && server < common {
+			common = server
 		}
 	}
 
-	err := validateHostNTPServers(hostsDateInfo)
-	if err != nil {
-		failures = append(failures, err.Error())
+	mismatched := make(map[string]string)
+	for _, dateInfo := range hostsDateInfo {
+		if !slices.Contains(dateInfo.NTPServers, common) {
+			mismatched[dateInfo.HostName] = fmt.Sprintf(
+				"NTP servers %v differ from the other hosts, which are configured with %s", dateInfo.NTPServers, common,
+			)
+		}
 	}
+	return mismatched
+}
 
-	if len(failures) > 0 {
-		return false, failures, err
+// validateClockSkew ensures that each host's clock is within maxSkew of vCenter's clock and of the median host clock
+func validateClockSkew(hostsDateInfo []vcenter.HostDateInfo, maxSkew time.Duration) map[string][]string {
+	problems := make(map[string][]string)
+
+	skews := make([]time.Duration, 0, len(hostsDateInfo))
+	for _, dateInfo := range hostsDateInfo {
+		if dateInfo.Current == nil {
+			continue
+		}
+		if dateInfo.Skew > maxSkew || dateInfo.Skew < -maxSkew {
+			problems[dateInfo.HostName] = append(problems[dateInfo.HostName], fmt.Sprintf(
+				"clock differs from vCenter by %s, exceeding the maximum allowed skew of %s", dateInfo.Skew, maxSkew,
+			))
+		}
+		skews = append(skews, dateInfo.Skew)
+	}
+	if len(skews) < 2 {
+		return problems
 	}
 
-	return true, failures, nil
+	slices.Sort(skews)
+	median := skews[(len(skews)-1)/2]
+	for _, dateInfo := range hostsDateInfo {
+		if dateInfo.Current == nil {
+			continue
+		}
+		if diff := dateInfo.Skew - median; diff > maxSkew || diff < -maxSkew {
+			problems[dateInfo.HostName] = append(problems[dateInfo.HostName], fmt.Sprintf(
+				"clock differs from the other hosts by %s, exceeding the maximum allowed skew of %s", diff, maxSkew,
+			))
+		}
+	}
+
+	return problems
 }
 
 func validateHostNTPServers(hostsDateInfo []vcenter.HostDateInfo) error {
@@ -225,3 +472,145 @@ func intersection(listA []string, listB []string) []string {
 	}
 	return intersect
 }
+
+// GetHostDNSConfigs returns the DNS configuration of the given hosts, or of all hosts in the cluster if no hosts are given.
+// The names of hosts that could not be found are returned separately.
+func (v *VCenterDriver) GetHostDNSConfigs(ctx context.Context, finder *find.Finder, datacenter, clusterName string, hosts []string) ([]vcenter.HostDNSConfig, []string, error) {
+	refs, notFound, err := v.getHostReferences(ctx, finder, datacenter, clusterName, hosts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(refs) == 0 {
+		return nil, notFound, nil
+	}
+
+	var hss []mo.HostSystem
+	pc := property.DefaultCollector(v.Client.Client)
+	if err := pc.Retrieve(ctx, refs, []string{"name", "config.network.dnsConfig", "config.network.vnic"}, &hss); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve host network configuration")
+	}
+
+	configs := make([]vcenter.HostDNSConfig, 0, len(hss))
+	for _, hs := range hss {
+		cfg := vcenter.HostDNSConfig{Name: hs.Name}
+		if hs.Config != nil && hs.Config.Network != nil {
+			if hs.Config.Network.DnsConfig != nil {
+				dns := hs.Config.Network.DnsConfig.GetHostDnsConfig()
+				cfg.HostName = dns.HostName
+				cfg.DomainName = dns.DomainName
+				cfg.DNSServers = dns.Address
+				cfg.SearchDomains = dns.SearchDomain
+			}
+			for _, vnic := range hs.Config.Network.Vnic {
+				if vnic.Spec.Ip != nil && vnic.Spec.Ip.IpAddress != "" {
+					cfg.IPAddresses = append(cfg.IPAddresses, vnic.Spec.Ip.IpAddress)
+				}
+			}
+		}
+		configs = append(configs, cfg)
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].Name < configs[j].Name })
+
+	return configs, notFound, nil
+}
+
+// GetHostNetworkConfigs returns the VMkernel adapters and physical NICs of the given hosts, or of all hosts in the cluster
+// if no hosts are given. The names of hosts that could not be found are returned separately.
+func (v *VCenterDriver) GetHostNetworkConfigs(ctx context.Context, finder *find.Finder, datacenter, clusterName string, hosts []string) ([]vcenter.HostNetworkConfig, []string, error) {
+	refs, notFound, err := v.getHostReferences(ctx, finder, datacenter, clusterName, hosts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(refs) == 0 {
+		return nil, notFound, nil
+	}
+
+	var hss []mo.HostSystem
+	pc := property.DefaultCollector(v.Client.Client)
+	ps := []string{"name", "config.network.vnic", "config.network.pnic", "config.virtualNicManagerInfo"}
+	if err := pc.Retrieve(ctx, refs, ps, &hss); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve host network configuration")
+	}
+
+	configs := make([]vcenter.HostNetworkConfig, 0, len(hss))
+	for _, hs := range hss {
+		cfg := vcenter.HostNetworkConfig{Name: hs.Name}
+		if hs.Config == nil {
+			configs = append(configs, cfg)
+			continue
+		}
+
+		services := make(map[string][]string)
+		if hs.Config.VirtualNicManagerInfo != nil {
+			for _, nc := range hs.Config.VirtualNicManagerInfo.NetConfig {
+				for _, candidate := range nc.CandidateVnic {
+					for _, selected := range nc.SelectedVnic {
+						if strings.HasSuffix(selected, candidate.Key) {
+							services[candidate.Device] = append(services[candidate.Device], nc.NicType)
+						}
+					}
+				}
+			}
+		}
+
+		if hs.Config.Network != nil {
+			for _, vnic := range hs.Config.Network.Vnic {
+				adapter := vcenter.VMKernelAdapter{
+					Device:   vnic.Device,
+					Services: services[vnic.Device],
+					MTU:      int(vnic.Spec.Mtu),
+				}
+				if vnic.Spec.Ip != nil {
+					adapter.IPAddress = vnic.Spec.Ip.IpAddress
+				}
+				cfg.VMKernelAdapters = append(cfg.VMKernelAdapters, adapter)
+			}
+			for _, pnic := range hs.Config.Network.Pnic {
+				nic := vcenter.PhysicalNIC{Device: pnic.Device}
+				if pnic.LinkSpeed != nil {
+					nic.SpeedMb = int(pnic.LinkSpeed.SpeedMb)
+				}
+				cfg.PhysicalNICs = append(cfg.PhysicalNICs, nic)
+			}
+		}
+		configs = append(configs, cfg)
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].Name < configs[j].Name })
+
+	return configs, notFound, nil
+}
+
+// getHostReferences returns the references of the given hosts, or of all hosts in the cluster if no hosts are given.
+// The names of hosts that could not be found are returned separately.
+func (v *VCenterDriver) getHostReferences(ctx context.Context, finder *find.Finder, datacenter, clusterName string, hosts []string) ([]types.ManagedObjectReference, []string, error) {
+	refs := make([]types.ManagedObjectReference, 0, len(hosts))
+	notFound := make([]string, 0)
+
+	if len(hosts) == 0 {
+		hostSystems, err := v.GetHostSystems(ctx, datacenter, clusterName)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, hs := range hostSystems {
+			var ref types.ManagedObjectReference
+			if !ref.FromString(hs.Reference) {
+				return nil, nil, fmt.Errorf("invalid host system reference: %s", hs.Reference)
+			}
+			refs = append(refs, ref)
+		}
+	}
+	for _, host := range hosts {
+		hostObj, err := v.GetHost(ctx, finder, datacenter, clusterName, host)
+		if err != nil {
+			var notFoundErr *find.NotFoundError
+			if errors.As(err, &notFoundErr) {
+				notFound = append(notFound, host)
+				continue
+			}
+			return nil, nil, err
+		}
+		refs = append(refs, hostObj.Reference())
+	}
+
+	return refs, notFound, nil
+}
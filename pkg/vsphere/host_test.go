@@ -1,15 +1,22 @@
 package vsphere
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
 
 	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
 )
 
 func TestGetHostSystem(t *testing.T) {
@@ -219,3 +226,347 @@ func TestValidateHostNTPServers(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateExpectedNTPServers(t *testing.T) {
+	tests := []struct {
+		name        string
+		servers     []string
+		expected    []string
+		match       string
+		expectError bool
+	}{
+		{
+			name:     "Exact match in any order",
+			servers:  []string{"ntp.b.com", "ntp.a.com"},
+			expected: []string{"ntp.a.com", "ntp.b.com"},
+			match:    NTPServerMatchExact,
+		},
+		{
+			name:        "Exact match missing server",
+			servers:     []string{"ntp.a.com"},
+			expected:    []string{"ntp.a.com", "ntp.b.com"},
+			match:       NTPServerMatchExact,
+			expectError: true,
+		},
+		{
+			name:        "Exact match is the default",
+			servers:     []string{"ntp.a.com"},
+			expected:    []string{"ntp.a.com", "ntp.b.com"},
+			expectError: true,
+		},
+		{
+			name:     "Subset match",
+			servers:  []string{"ntp.a.com"},
+			expected: []string{"ntp.a.com", "ntp.b.com"},
+			match:    NTPServerMatchSubset,
+		},
+		{
+			name:        "Subset match with unexpected server",
+			servers:     []string{"ntp.a.com", "ntp.c.com"},
+			expected:    []string{"ntp.a.com", "ntp.b.com"},
+			match:       NTPServerMatchSubset,
+			expectError: true,
+		},
+		{
+			name:        "Subset match with no servers",
+			servers:     nil,
+			expected:    []string{"ntp.a.com"},
+			match:       NTPServerMatchSubset,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateExpectedNTPServers(vcenter.HostDateInfo{HostName: "host0", NTPServers: tt.servers}, tt.expected, tt.match)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateClockSkew(t *testing.T) {
+	now := time.Now()
+	info := func(name string, skew time.Duration) vcenter.HostDateInfo {
+		return vcenter.HostDateInfo{HostName: name, Current: &now, Skew: skew}
+	}
+
+	tests := []struct {
+		name          string
+		hostsDateInfo []vcenter.HostDateInfo
+		maxSkew       time.Duration
+		expected      map[string][]string
+	}{
+		{
+			name:          "Within threshold",
+			hostsDateInfo: []vcenter.HostDateInfo{info("host0", 100*time.Millisecond), info("host1", -100*time.Millisecond)},
+			maxSkew:       time.Second,
+			expected:      map[string][]string{},
+		},
+		{
+			name:          "Unknown host time is ignored",
+			hostsDateInfo: []vcenter.HostDateInfo{info("host0", 0), {HostName: "host1", Skew: time.Hour}},
+			maxSkew:       time.Second,
+			expected:      map[string][]string{},
+		},
+		{
+			name: "Host exceeds threshold relative to the other hosts",
+			hostsDateInfo: []vcenter.HostDateInfo{
+				info("host0", 600*time.Millisecond), info("host1", -600*time.Millisecond), info("host2", -500*time.Millisecond),
+			},
+			maxSkew: time.Second,
+			expected: map[string][]string{
+				"host0": {"clock differs from the other hosts by 1.1s, exceeding the maximum allowed skew of 1s"},
+			},
+		},
+		{
+			name:          "Host exceeds threshold relative to vCenter",
+			hostsDateInfo: []vcenter.HostDateInfo{info("host0", 2*time.Second), {HostName: "host1"}},
+			maxSkew:       time.Second,
+			expected: map[string][]string{
+				"host0": {"clock differs from vCenter by 2s, exceeding the maximum allowed skew of 1s"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validateClockSkew(tt.hostsDateInfo, tt.maxSkew))
+		})
+	}
+}
+
+func TestMismatchedNTPServers(t *testing.T) {
+	hostsDateInfo := []vcenter.HostDateInfo{
+		{HostName: "host0", NTPServers: []string{"ntp.a.com", "ntp.b.com"}},
+		{HostName: "host1", NTPServers: []string{"ntp.b.com"}},
+		{HostName: "host2", NTPServers: []string{"ntp.c.com"}},
+		{HostName: "host3", NTPServers: nil},
+	}
+	expected := map[string]string{
+		"host2": "NTP servers [ntp.c.com] differ from the other hosts, which are configured with ntp.b.com",
+		"host3": "NTP servers [] differ from the other hosts, which are configured with ntp.b.com",
+	}
+	assert.Equal(t, expected, mismatchedNTPServers(hostsDateInfo))
+}
+
+func TestValidateHostTimeProtocol(t *testing.T) {
+	synced := false
+
+	tests := []struct {
+		name     string
+		info     vcenter.HostDateInfo
+		protocol string
+		expected []string
+	}{
+		{
+			name:     "Legacy host using NTP",
+			info:     vcenter.HostDateInfo{HostName: "host0"},
+			protocol: TimeProtocolNTP,
+		},
+		{
+			name:     "Legacy host without PTP support",
+			info:     vcenter.HostDateInfo{HostName: "host0"},
+			protocol: TimeProtocolPTP,
+			expected: []string{
+				"PTP is not configured; PTP requires ESXi 7.0 Update 3 or later",
+				"uses ntp for time synchronization, expected ptp",
+			},
+		},
+		{
+			name: "PTP host",
+			info: vcenter.HostDateInfo{
+				HostName: "host0",
+				HostDateTimeInfo: types.HostDateTimeInfo{
+					SystemClockProtocol: TimeProtocolPTP,
+					PtpConfig:           &types.HostPtpConfig{},
+				},
+			},
+			protocol: TimeProtocolPTP,
+		},
+		{
+			name: "Unsynchronized NTP host",
+			info: vcenter.HostDateInfo{
+				HostName: "host0",
+				HostDateTimeInfo: types.HostDateTimeInfo{
+					SystemClockProtocol: TimeProtocolNTP,
+					ServiceSync:         &synced,
+				},
+			},
+			protocol: TimeProtocolNTP,
+			expected: []string{"not synchronized with its NTP time source"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validateHostTimeProtocol(tt.info, tt.protocol))
+		})
+	}
+}
+
+// simDateTimeSystem is a HostDateTimeSystem for vcsim, which doesn't implement one
+type simDateTimeSystem struct {
+	mo.HostDateTimeSystem
+}
+
+func (s *simDateTimeSystem) QueryDateTime(*types.QueryDateTime) soap.HasFault {
+	return &methods.QueryDateTimeBody{Res: &types.QueryDateTimeResponse{Returnval: time.Now()}}
+}
+
+// simServiceSystem is a HostServiceSystem for vcsim, which doesn't implement one
+type simServiceSystem struct {
+	mo.HostServiceSystem
+}
+
+func TestValidateHostNTPSettings(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8480, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	// every simulated host shares the same date time and service systems
+	simulator.Map.Put(&simDateTimeSystem{HostDateTimeSystem: mo.HostDateTimeSystem{
+		Self: types.ManagedObjectReference{Type: "HostDateTimeSystem", Value: "dateTimeSystem"},
+		DateTimeInfo: types.HostDateTimeInfo{
+			NtpConfig: &types.HostNtpConfig{Server: []string{"0.pool.ntp.org", "1.pool.ntp.org"}},
+		},
+	}})
+	simulator.Map.Put(&simServiceSystem{HostServiceSystem: mo.HostServiceSystem{
+		ExtensibleManagedObject: mo.ExtensibleManagedObject{
+			Self: types.ManagedObjectReference{Type: "HostServiceSystem", Value: "serviceSystem"},
+		},
+		ServiceInfo: types.HostServiceInfo{
+			Service: []types.HostService{{Key: "ntpd", Label: "NTP Daemon", Policy: "on", Running: true}},
+		},
+	}})
+
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		hosts            []string
+		opts             NTPOptions
+		expectedValid    bool
+		expectedFailures []string
+	}{
+		{
+			name:             "All hosts in the cluster",
+			opts:             NTPOptions{MaxClockSkew: time.Minute},
+			expectedValid:    true,
+			expectedFailures: []string{},
+		},
+		{
+			name:             "Missing host",
+			hosts:            []string{"DC0_C0_H0", "missing"},
+			expectedFailures: []string{"Host: missing: not found"},
+		},
+		{
+			name:  "Unexpected NTP servers",
+			hosts: []string{"DC0_C0_H0"},
+			opts:  NTPOptions{ExpectedServers: []string{"time.example.com"}},
+			expectedFailures: []string{
+				"Host: DC0_C0_H0: NTP servers [0.pool.ntp.org 1.pool.ntp.org], expected [time.example.com]",
+			},
+		},
+		{
+			name:             "No PTP service",
+			hosts:            []string{"DC0_C0_H0", "missing"},
+			opts:             NTPOptions{Protocol: TimeProtocolPTP},
+			expectedFailures: []string{"Host: DC0_C0_H0: no PTP service operating on it", "Host: missing: not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, failures, err := driver.ValidateHostNTPSettings(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster, tt.hosts, tt.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedValid, valid)
+			assert.Equal(t, tt.expectedFailures, failures)
+		})
+	}
+}
+
+func TestGetHostDNSConfigs(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8461, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configs, notFound, err := driver.GetHostDNSConfigs(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, notFound)
+	assert.Equal(t, []vcenter.HostDNSConfig{
+		{
+			Name:          "DC0_C0_H0",
+			HostName:      "localhost",
+			DomainName:    "localdomain",
+			DNSServers:    []string{"8.8.8.8"},
+			SearchDomains: []string{"localdomain"},
+			IPAddresses:   []string{"127.0.0.1"},
+		},
+	}, configs)
+
+	configs, notFound, err = driver.GetHostDNSConfigs(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster, []string{"missing"})
+	assert.NoError(t, err)
+	assert.Empty(t, configs)
+	assert.Equal(t, []string{"missing"}, notFound)
+}
+
+func TestGetHostNetworkConfigs(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8462, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configs, notFound, err := driver.GetHostNetworkConfigs(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, notFound)
+	assert.Equal(t, []vcenter.HostNetworkConfig{
+		{
+			Name: "DC0_C0_H0",
+			VMKernelAdapters: []vcenter.VMKernelAdapter{
+				{Device: "vmk0", Services: []string{"management"}, MTU: 1500, IPAddress: "127.0.0.1"},
+			},
+			PhysicalNICs: []vcenter.PhysicalNIC{
+				{Device: "vmnic0", SpeedMb: 10000},
+				{Device: "vmnic1", SpeedMb: 10000},
+			},
+		},
+	}, configs)
+
+	_, notFound, err = driver.GetHostNetworkConfigs(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster, []string{"missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"missing"}, notFound)
+}
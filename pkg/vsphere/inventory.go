@@ -0,0 +1,208 @@
+package vsphere
+
+import (
+	"context"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// Inventory is a snapshot of the vCenter inventory, retrieved with a single property collector request.
+// It records the inventory path of every managed entity, the hosts and datastores of every cluster, and the
+// summaries of every host and datastore, so that lookups made while validating many rules do not each query vCenter.
+type Inventory struct {
+	paths      map[string]types.ManagedObjectReference
+	clusters   map[types.ManagedObjectReference]mo.ClusterComputeResource
+	hosts      map[types.ManagedObjectReference]mo.HostSystem
+	datastores map[types.ManagedObjectReference]mo.Datastore
+}
+
+// inventoryTypes are the types of the managed entities in an inventory snapshot
+var inventoryTypes = []string{
+	"Datacenter", "Folder", "ClusterComputeResource", "ComputeResource", "HostSystem", "ResourcePool", "Datastore", "Network",
+}
+
+// vmInventoryTypes are the types of the managed entities that are only snapshotted on request, as there may be many
+var vmInventoryTypes = []string{"VirtualMachine", "VirtualApp"}
+
+// inventoryEntity is the name and parent of a managed entity
+type inventoryEntity struct {
+	name   string
+	parent *types.ManagedObjectReference
+}
+
+// LoadInventory retrieves a snapshot of the vCenter inventory that subsequent lookups are served from.
+// VMs and vApps are only included if includeVMs is set. Lookups for objects that are not in the snapshot fall back to
+// querying vCenter.
+func (v *VCenterDriver) LoadInventory(ctx context.Context, includeVMs bool) error {
+	inv, err := NewInventory(ctx, v, includeVMs)
+	if err != nil {
+		return err
+	}
+	v.inventory = inv
+	return nil
+}
+
+// NewInventory retrieves a snapshot of the vCenter inventory, including VMs and vApps if includeVMs is set
+func NewInventory(ctx context.Context, v *VCenterDriver, includeVMs bool) (*Inventory, error) {
+	c := v.Client.Client
+	root := c.ServiceContent.RootFolder
+
+	kinds := inventoryTypes
+	if includeVMs {
+		kinds = append(slices.Clip(kinds), vmInventoryTypes...)
+	}
+	cv, err := view.NewManager(c).CreateContainerView(ctx, root, kinds, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create inventory container view")
+	}
+	defer func() { _ = cv.Destroy(ctx) }()
+
+	req := types.RetrieveProperties{
+		SpecSet: []types.PropertyFilterSpec{{
+			ObjectSet: []types.ObjectSpec{{
+				Obj:  cv.Reference(),
+				Skip: types.NewBool(true),
+				SelectSet: []types.BaseSelectionSpec{
+					&types.TraversalSpec{Type: "ContainerView", Path: "view"},
+				},
+			}},
+			PropSet: []types.PropertySpec{
+				{Type: "ManagedEntity", PathSet: []string{"name", "parent"}},
+				{Type: "ClusterComputeResource", PathSet: []string{"name", "parent", "host", "datastore"}},
+				{Type: "HostSystem", PathSet: []string{"name", "parent", "summary"}},
+				{Type: "Datastore", PathSet: []string{"name", "parent", "summary"}},
+			},
+		}},
+	}
+	res, err := property.DefaultCollector(c).RetrieveProperties(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve inventory")
+	}
+
+	inv := &Inventory{
+		paths:      make(map[string]types.ManagedObjectReference),
+		clusters:   make(map[types.ManagedObjectReference]mo.ClusterComputeResource),
+		hosts:      make(map[types.ManagedObjectReference]mo.HostSystem),
+		datastores: make(map[types.ManagedObjectReference]mo.Datastore),
+	}
+
+	entities := make(map[types.ManagedObjectReference]inventoryEntity, len(res.Returnval))
+	for _, oc := range res.Returnval {
+		obj, err := mo.ObjectContentToType(oc, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode inventory")
+		}
+		switch o := obj.(type) {
+		case *mo.ClusterComputeResource:
+			inv.clusters[oc.Obj] = *o
+		case *mo.HostSystem:
+			inv.hosts[oc.Obj] = *o
+		case *mo.Datastore:
+			inv.datastores[oc.Obj] = *o
+		}
+		if e, ok := obj.(mo.Entity); ok {
+			entities[oc.Obj] = inventoryEntity{name: e.Entity().Name, parent: e.Entity().Parent}
+		}
+	}
+
+	// the root folder is not part of the container view, so it terminates every path
+	resolved := map[types.ManagedObjectReference]string{root: ""}
+	for ref := range entities {
+		if p, ok := resolvePath(ref, entities, resolved); ok {
+			inv.paths[p] = ref
+		}
+	}
+
+	return inv, nil
+}
+
+// resolvePath returns the inventory path of an entity, or false if its parents do not lead to the root folder,
+// e.g., for VMs in a vApp
+func resolvePath(ref types.ManagedObjectReference, entities map[types.ManagedObjectReference]inventoryEntity, resolved map[types.ManagedObjectReference]string) (string, bool) {
+	if p, ok := resolved[ref]; ok {
+		return p, true
+	}
+	e, ok := entities[ref]
+	if !ok || e.parent == nil {
+		return "", false
+	}
+	parent, ok := resolvePath(*e.parent, entities, resolved)
+	if !ok {
+		return "", false
+	}
+	p := parent + "/" + e.name
+	resolved[ref] = p
+	return p, true
+}
+
+// lookup returns the reference of the object at an inventory path. It is safe to call on a nil Inventory.
+func (i *Inventory) lookup(p string) (types.ManagedObjectReference, bool) {
+	if i == nil {
+		return types.ManagedObjectReference{}, false
+	}
+	ref, ok := i.paths[path.Clean(p)]
+	return ref, ok
+}
+
+// clusterResources returns the summaries of a cluster's datastores and hosts
+func (i *Inventory) clusterResources(ref types.ManagedObjectReference) ([]mo.Datastore, []mo.HostSystem, bool) {
+	if i == nil {
+		return nil, nil, false
+	}
+	cluster, ok := i.clusters[ref]
+	if !ok {
+		return nil, nil, false
+	}
+
+	datastores := make([]mo.Datastore, 0, len(cluster.Datastore))
+	for _, ref := range cluster.Datastore {
+		ds, ok := i.datastores[ref]
+		if !ok {
+			return nil, nil, false
+		}
+		datastores = append(datastores, ds)
+	}
+	hosts := make([]mo.HostSystem, 0, len(cluster.Host))
+	for _, ref := range cluster.Host {
+		hs, ok := i.hosts[ref]
+		if !ok {
+			return nil, nil, false
+		}
+		hosts = append(hosts, hs)
+	}
+
+	return datastores, hosts, true
+}
+
+// cachedObject returns the object at an inventory path from the inventory snapshot, if one was loaded
+func (v *VCenterDriver) cachedObject(p string) (object.Reference, bool) {
+	ref, ok := v.inventory.lookup(p)
+	if !ok {
+		return nil, false
+	}
+	obj := object.NewReference(v.Client.Client, ref)
+	if c, ok := obj.(interface{ SetInventoryPath(string) }); ok {
+		c.SetInventoryPath(path.Clean(p))
+	}
+	return obj, true
+}
+
+// datacenterPath returns the inventory path of an object given its name, which is relative to a folder
+// of the driver's datacenter unless it is an absolute path
+func (v *VCenterDriver) datacenterPath(folder, name string) (string, bool) {
+	if strings.HasPrefix(name, "/") {
+		return name, true
+	}
+	if v.Datacenter == "" {
+		return "", false
+	}
+	return path.Join("/", v.Datacenter, folder, name), true
+}
@@ -0,0 +1,90 @@
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+)
+
+func TestInventory(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8473, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// uncached lookups
+	want, err := driver.GetCluster(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDatastores, wantHosts, err := driver.GetClusterResources(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := driver.LoadInventory(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+
+	ref, ok := driver.inventory.lookup("/DC0/host/DC0_C0")
+	assert.True(t, ok)
+	assert.Equal(t, want.Reference(), ref)
+
+	_, ok = driver.inventory.lookup("/DC0/host/missing")
+	assert.False(t, ok)
+
+	// VMs are only snapshotted on request
+	_, ok = driver.inventory.lookup("/DC0/vm/DC0_C0_RP1_VM0")
+	assert.False(t, ok)
+
+	// cached lookups
+	cluster, err := driver.GetCluster(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, want.Reference(), cluster.Reference())
+	assert.Equal(t, want.InventoryPath, cluster.InventoryPath)
+
+	datastores, hosts, err := driver.GetClusterResources(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, datastores, len(wantDatastores))
+	assert.Len(t, hosts, len(wantHosts))
+	for i := range hosts {
+		assert.Equal(t, wantHosts[i].Summary.Hardware.CpuMhz, hosts[i].Summary.Hardware.CpuMhz)
+	}
+
+	hostSystems, err := driver.GetHostSystems(ctx, vcSim.Options.Datacenter, vcSim.Options.Cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, hostSystems, len(wantHosts))
+
+	vm, err := driver.GetVM(ctx, finder, "DC0_C0_RP1_VM0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "/DC0/vm/DC0_C0_RP1_VM0", vm.InventoryPath)
+
+	if err := driver.LoadInventory(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	ref, ok = driver.inventory.lookup("/DC0/vm/DC0_C0_RP1_VM0")
+	assert.True(t, ok)
+	assert.Equal(t, vm.Reference(), ref)
+}
@@ -0,0 +1,103 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/license"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+const (
+	licensePropertyFeature        = "feature"
+	licensePropertyExpirationDate = "expirationDate"
+)
+
+// GetHostLicenses returns the licenses assigned to the given hosts, or to all hosts in the cluster if no hosts are given.
+// The names of hosts that could not be found, and of hosts that have no license assigned, are returned separately.
+func (v *VCenterDriver) GetHostLicenses(ctx context.Context, finder *find.Finder, datacenter, clusterName string, hosts []string) ([]vcenter.HostLicense, []string, []string, error) {
+	refs, notFound, err := v.getHostReferences(ctx, finder, datacenter, clusterName, hosts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(refs) == 0 {
+		return nil, notFound, nil, nil
+	}
+
+	var hss []mo.HostSystem
+	pc := property.DefaultCollector(v.Client.Client)
+	if err := pc.Retrieve(ctx, refs, []string{"name"}, &hss); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to retrieve host names")
+	}
+
+	m := license.NewManager(v.Client.Client)
+	infos, err := m.List(ctx)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to list licenses")
+	}
+	// the license manager reports usage across all assignments, while assignments only report the license itself
+	licenses := make(map[string]types.LicenseManagerLicenseInfo, len(infos))
+	for _, info := range infos {
+		licenses[info.LicenseKey] = info
+	}
+
+	am, err := m.AssignmentManager(ctx)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to get license assignment manager")
+	}
+
+	hostLicenses := make([]vcenter.HostLicense, 0, len(hss))
+	unlicensed := make([]string, 0)
+	for _, hs := range hss {
+		assignments, err := am.QueryAssigned(ctx, hs.Reference().Value)
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, fmt.Sprintf("failed to query license assigned to host %s", hs.Name))
+		}
+		if len(assignments) == 0 {
+			unlicensed = append(unlicensed, hs.Name)
+		}
+		for _, a := range assignments {
+			info, ok := licenses[a.AssignedLicense.LicenseKey]
+			if !ok {
+				info = a.AssignedLicense
+			}
+			hostLicenses = append(hostLicenses, vcenter.HostLicense{Host: hs.Name, License: toLicense(info)})
+		}
+	}
+	sort.SliceStable(hostLicenses, func(i, j int) bool { return hostLicenses[i].Host < hostLicenses[j].Host })
+	sort.Strings(unlicensed)
+
+	return hostLicenses, notFound, unlicensed, nil
+}
+
+func toLicense(info types.LicenseManagerLicenseInfo) vcenter.License {
+	l := vcenter.License{
+		Name:       info.Name,
+		EditionKey: info.EditionKey,
+		CostUnit:   info.CostUnit,
+		Total:      info.Total,
+		Used:       info.Used,
+		Features:   make([]string, 0),
+	}
+	for _, p := range info.Properties {
+		switch p.Key {
+		case licensePropertyFeature:
+			if kv, ok := p.Value.(types.KeyValue); ok {
+				l.Features = append(l.Features, kv.Key)
+			}
+		case licensePropertyExpirationDate:
+			if t, ok := p.Value.(time.Time); ok {
+				l.ExpirationDate = &t
+			}
+		}
+	}
+	return l
+}
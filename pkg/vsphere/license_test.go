@@ -0,0 +1,45 @@
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+)
+
+func TestGetHostLicenses(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8469, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	licenses, notFound, unlicensed, err := driver.GetHostLicenses(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster, []string{"DC0_C0_H0", "missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, []vcenter.HostLicense{
+		{
+			Host: "DC0_C0_H0",
+			License: vcenter.License{
+				Name:       "Evaluation Mode",
+				EditionKey: vcenter.LicenseEditionEvaluation,
+				Features:   []string{"serialuri:2", "dvs"},
+			},
+		},
+	}, licenses)
+	assert.Equal(t, []string{"missing"}, notFound)
+	assert.Empty(t, unlicensed)
+	assert.True(t, licenses[0].License.Evaluation())
+}
@@ -16,6 +16,13 @@ import (
 
 // GetNetwork returns a network object if it exists
 func (v *VCenterDriver) GetNetwork(ctx context.Context, finder *find.Finder, path string) (*object.Network, error) {
+	if p, ok := v.datacenterPath("network", path); ok {
+		if obj, ok := v.cachedObject(p); ok {
+			if network, ok := obj.(*object.Network); ok {
+				return network, nil
+			}
+		}
+	}
 	nr, err := finder.Network(ctx, path)
 	if err != nil {
 		return nil, err
@@ -98,6 +105,13 @@ func (v *VCenterDriver) getNetworkReferences(ctx context.Context, datacenter str
 
 // GetDistributedVirtualPortgroup returns a distributed virtual port group object if it exists
 func (v *VCenterDriver) GetDistributedVirtualPortgroup(ctx context.Context, finder *find.Finder, path string) (*object.DistributedVirtualPortgroup, error) {
+	if p, ok := v.datacenterPath("network", path); ok {
+		if obj, ok := v.cachedObject(p); ok {
+			if dvp, ok := obj.(*object.DistributedVirtualPortgroup); ok {
+				return dvp, nil
+			}
+		}
+	}
 	nr, err := finder.Network(ctx, path)
 	if err != nil {
 		return nil, err
@@ -178,3 +178,32 @@ func TestGetDistributedVirtualSwitchNameFromPortGroup(t *testing.T) {
 		t.Errorf("GetDistributedVirtualSwitchNameFromPortGroup() got %s != expected %s", dvsName, expected)
 	}
 }
+
+func TestGetNSXSegment(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8471, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	segment, err := driver.GetNSXSegment(ctx, finder, vcSim.Options.Datacenter, "DC0_DVPG0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if segment.Type != "Distributed Port Group" || segment.NSX || len(segment.Hosts) == 0 {
+		t.Errorf("GetNSXSegment() got %+v, expected a non-NSX distributed port group attached to hosts", segment)
+	}
+
+	if _, err := driver.GetNSXSegment(ctx, finder, vcSim.Options.Datacenter, "missing"); err == nil {
+		t.Error("GetNSXSegment() expected error for missing network")
+	}
+}
@@ -0,0 +1,73 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+// nsxOpaqueNetworkTypePrefix prefixes the types of opaque networks managed by NSX, e.g., nsx.LogicalSwitch
+const nsxOpaqueNetworkTypePrefix = "nsx."
+
+// GetNSXSegment returns an NSX segment's network type, logical switch ID and the names of the hosts it is attached to.
+// Networks that are neither opaque networks nor NSX distributed port groups are returned with NSX set to false.
+func (v *VCenterDriver) GetNSXSegment(ctx context.Context, finder *find.Finder, datacenter, name string) (*vcenter.NSXSegment, error) {
+	networkType, err := v.GetNetworkTypeByName(ctx, datacenter, name)
+	if err != nil {
+		return nil, err
+	}
+	segment := &vcenter.NSXSegment{Name: name, Type: networkType, Hosts: make([]string, 0)}
+
+	nr, err := finder.Network(ctx, fmt.Sprintf(vcenter.NetworkInventoryPath, datacenter, name))
+	if err != nil {
+		return nil, err
+	}
+
+	var hostRefs []types.ManagedObjectReference
+	pc := property.DefaultCollector(v.Client.Client)
+	switch n := nr.(type) {
+	case *object.OpaqueNetwork:
+		var on mo.OpaqueNetwork
+		if err := pc.RetrieveOne(ctx, n.Reference(), []string{"summary", "host"}, &on); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to retrieve opaque network %s", name))
+		}
+		if summary, ok := on.Summary.(*types.OpaqueNetworkSummary); ok {
+			segment.NSX = strings.HasPrefix(summary.OpaqueNetworkType, nsxOpaqueNetworkTypePrefix)
+			segment.LogicalSwitchID = summary.OpaqueNetworkId
+		}
+		hostRefs = on.Host
+	case *object.DistributedVirtualPortgroup:
+		var dvpg mo.DistributedVirtualPortgroup
+		if err := pc.RetrieveOne(ctx, n.Reference(), []string{"config", "host"}, &dvpg); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to retrieve distributed port group %s", name))
+		}
+		segment.NSX = dvpg.Config.BackingType == string(types.DistributedVirtualPortgroupBackingTypeNsx)
+		segment.LogicalSwitchID = dvpg.Config.LogicalSwitchUuid
+		hostRefs = dvpg.Host
+	default:
+		return segment, nil
+	}
+
+	if len(hostRefs) > 0 {
+		var hss []mo.HostSystem
+		if err := pc.Retrieve(ctx, hostRefs, []string{"name"}, &hss); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to retrieve hosts of network %s", name))
+		}
+		for _, hs := range hss {
+			segment.Hosts = append(segment.Hosts, hs.Name)
+		}
+		sort.Strings(segment.Hosts)
+	}
+
+	return segment, nil
+}
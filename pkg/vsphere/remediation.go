@@ -0,0 +1,32 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+)
+
+// RemediationStep is a change to vCenter that remediates a validation rule failure.
+type RemediationStep struct {
+	// Description describes the change, e.g., "create tag category k8s-zone".
+	Description string
+
+	// Apply makes the change.
+	Apply func(ctx context.Context) error
+}
+
+// Remediate applies remediation steps in order, stopping at the first step that fails. It returns a record of each
+// step applied or, if dryRun is true, of each step that would be applied.
+func Remediate(ctx context.Context, steps []RemediationStep, dryRun bool) ([]string, error) {
+	record := make([]string, 0, len(steps))
+	for _, step := range steps {
+		if dryRun {
+			record = append(record, fmt.Sprintf("remediation planned (dry run): %s", step.Description))
+			continue
+		}
+		if err := step.Apply(ctx); err != nil {
+			return record, fmt.Errorf("failed to %s: %w", step.Description, err)
+		}
+		record = append(record, fmt.Sprintf("remediation applied: %s", step.Description))
+	}
+	return record, nil
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 
 	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
 )
@@ -23,6 +25,11 @@ func (v *VCenterDriver) GetResourcePool(ctx context.Context, finder *find.Finder
 		path = fmt.Sprintf(vcenter.HostChildInventoryPath, datacenter, cluster, resourcePoolName)
 	}
 
+	if obj, ok := v.cachedObject(path); ok {
+		if rp, ok := obj.(*object.ResourcePool); ok {
+			return rp, nil
+		}
+	}
 	rp, err := finder.ResourcePool(ctx, path)
 	if err != nil {
 		return nil, err
@@ -30,6 +37,44 @@ func (v *VCenterDriver) GetResourcePool(ctx context.Context, finder *find.Finder
 	return rp, nil
 }
 
+// GetResourcePoolConfig returns the CPU and memory allocation of a resource pool.
+// Nested resource pools may be specified using their path beneath the cluster, e.g., parent/child.
+func (v *VCenterDriver) GetResourcePoolConfig(ctx context.Context, finder *find.Finder, datacenter, cluster, resourcePoolName string) (*vcenter.ResourcePoolConfig, error) {
+	rp, err := v.GetResourcePool(ctx, finder, datacenter, cluster, resourcePoolName)
+	if err != nil {
+		return nil, err
+	}
+
+	var mrp mo.ResourcePool
+	if err := rp.Properties(ctx, rp.Reference(), []string{"config"}, &mrp); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to get config of resource pool %s", rp.InventoryPath))
+	}
+
+	return &vcenter.ResourcePoolConfig{
+		InventoryPath: rp.InventoryPath,
+		CPU:           toResourceAllocation(mrp.Config.CpuAllocation),
+		Memory:        toResourceAllocation(mrp.Config.MemoryAllocation),
+	}, nil
+}
+
+func toResourceAllocation(info types.ResourceAllocationInfo) vcenter.ResourceAllocation {
+	allocation := vcenter.ResourceAllocation{Limit: -1}
+	if info.Reservation != nil {
+		allocation.Reservation = *info.Reservation
+	}
+	if info.Limit != nil {
+		allocation.Limit = *info.Limit
+	}
+	if info.ExpandableReservation != nil {
+		allocation.ExpandableReservation = *info.ExpandableReservation
+	}
+	if info.Shares != nil {
+		allocation.SharesLevel = string(info.Shares.Level)
+		allocation.Shares = info.Shares.Shares
+	}
+	return allocation
+}
+
 // GetResourcePools returns a list of resource pools
 func (v *VCenterDriver) GetResourcePools(ctx context.Context, datacenter string, cluster string) ([]*object.ResourcePool, error) {
 	path := fmt.Sprintf(vcenter.ResourcePoolInventoryGlob, datacenter, cluster)
@@ -0,0 +1,61 @@
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+)
+
+func TestGetResourcePoolConfig(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8465, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// create a nested resource pool
+	parent, err := driver.GetResourcePool(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster, "DC0_C0_RP1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := types.DefaultResourceConfigSpec()
+	spec.CpuAllocation.Reservation = types.NewInt64(1000)
+	spec.MemoryAllocation.Limit = types.NewInt64(2048)
+	spec.MemoryAllocation.ExpandableReservation = types.NewBool(false)
+	spec.MemoryAllocation.Shares = &types.SharesInfo{Level: types.SharesLevelHigh}
+	if _, err := parent.Create(ctx, "child", spec); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := driver.GetResourcePoolConfig(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster, "DC0_C0_RP1/child")
+	assert.NoError(t, err)
+	assert.Equal(t, &vcenter.ResourcePoolConfig{
+		InventoryPath: "/DC0/host/DC0_C0/Resources/DC0_C0_RP1/child",
+		CPU:           vcenter.ResourceAllocation{Reservation: 1000, Limit: -1, SharesLevel: "normal", ExpandableReservation: true},
+		Memory:        vcenter.ResourceAllocation{Reservation: 0, Limit: 2048, SharesLevel: "high", ExpandableReservation: false},
+	}, cfg)
+
+	cfg, err = driver.GetResourcePoolConfig(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster, vcenter.ClusterDefaultResourcePoolName)
+	assert.NoError(t, err)
+	assert.Equal(t, "/DC0/host/DC0_C0/Resources", cfg.InventoryPath)
+
+	_, err = driver.GetResourcePoolConfig(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster, "missing")
+	var notFoundErr *find.NotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
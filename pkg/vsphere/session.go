@@ -0,0 +1,422 @@
+package vsphere
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/session/keepalive"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/soap"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+const (
+	// DefaultSessionIdleTimeout is the duration after which an unused session is logged out and evicted.
+	DefaultSessionIdleTimeout = 30 * time.Minute
+
+	// sessionLogoutTimeout bounds the time spent logging out of an evicted session.
+	sessionLogoutTimeout = 10 * time.Second
+)
+
+// defaultSessionManager is the SessionManager used by NewVCenterDriver.
+var defaultSessionManager = NewSessionManager(DefaultSessionIdleTimeout, logr.Discard())
+
+// SessionKey uniquely identifies a vCenter session.
+type SessionKey struct {
+	Host            string
+	Port            string
+	Username        string
+	CredentialsHash [sha256.Size]byte
+}
+
+// NewSessionKey returns the SessionKey for a vCenter account.
+func NewSessionKey(account vcenter.Account) (SessionKey, error) {
+	u, err := getVCenterURL(account)
+	if err != nil {
+		return SessionKey{}, err
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+	return SessionKey{
+		Host:            u.Hostname(),
+		Port:            port,
+		Username:        account.Username,
+		CredentialsHash: credentialsHash(account),
+	}, nil
+}
+
+// credentialsHash returns a hash of all of an account's credentials. Each credential is length prefixed
+// so that adjacent values cannot collide.
+func credentialsHash(account vcenter.Account) [sha256.Size]byte {
+	h := sha256.New()
+	for _, c := range []string{
+		account.Password, account.Token, account.Certificate, account.PrivateKey,
+		account.FederatedToken, account.FederatedTokenType,
+	} {
+		fmt.Fprintf(h, "%d:%s", len(c), c)
+	}
+	var sum [sha256.Size]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// Session is a struct that contains the govmomi and rest clients
+type Session struct {
+	GovmomiClient *govmomi.Client
+	RestClient    *rest.Client
+}
+
+// cachedSession is a SessionManager's state for a session. Its clients are only accessed while holding mu, since
+// they are replaced when the session expires and cleared when it is logged out; callers are handed a copy instead.
+type cachedSession struct {
+	Session
+
+	mu       sync.Mutex
+	key      SessionKey
+	lastUsed time.Time
+}
+
+// SessionManager caches authenticated vCenter sessions, one per SessionKey. Sessions may be acquired on behalf
+// of an owner, in which case they are reference counted and only logged out once their last owner releases them.
+type SessionManager struct {
+	mu          sync.Mutex
+	sessions    map[SessionKey]*cachedSession
+	owners      map[string]SessionKey
+	idleTimeout time.Duration
+	now         func() time.Time
+	log         logr.Logger
+}
+
+// NewSessionManager creates a new SessionManager. Sessions without owners that are unused for longer than
+// idleTimeout are logged out and evicted. An idleTimeout of zero disables idle expiry.
+func NewSessionManager(idleTimeout time.Duration, log logr.Logger) *SessionManager {
+	return &SessionManager{
+		sessions:    make(map[SessionKey]*cachedSession),
+		owners:      make(map[string]SessionKey),
+		idleTimeout: idleTimeout,
+		now:         time.Now,
+		log:         log,
+	}
+}
+
+// GetOrCreateSession returns the session for the given server, username and password
+func GetOrCreateSession(ctx context.Context, account vcenter.Account, refreshRestClient bool) (Session, error) {
+	return defaultSessionManager.GetOrCreate(ctx, account, refreshRestClient)
+}
+
+// AcquireSession returns the session for the given account and records a reference to it on behalf of owner
+func AcquireSession(ctx context.Context, owner string, account vcenter.Account) (Session, error) {
+	return defaultSessionManager.Acquire(ctx, owner, account)
+}
+
+// ReleaseSession releases owner's reference to its session, logging out if no other owner references it
+func ReleaseSession(ctx context.Context, owner string) {
+	defaultSessionManager.Release(ctx, owner)
+}
+
+// CloseSessions logs out of all cached sessions
+func CloseSessions(ctx context.Context) {
+	defaultSessionManager.Close(ctx)
+}
+
+// GetOrCreate returns the clients of a cached session for the account if it is still active, otherwise it logs in
+// and caches a new one. If refreshRestClient is true, the REST client is also validated and logged in again if its
+// session has expired. The clients returned are those of the session at the time of the call; the session may be
+// logged out and replaced afterwards, in which case they stop working and GetOrCreate must be called again.
+func (m *SessionManager) GetOrCreate(ctx context.Context, account vcenter.Account, refreshRestClient bool) (Session, error) {
+	key, err := NewSessionKey(account)
+	if err != nil {
+		return Session{}, err
+	}
+	return m.getOrCreate(ctx, key, account, refreshRestClient)
+}
+
+func (m *SessionManager) getOrCreate(ctx context.Context, key SessionKey, account vcenter.Account, refreshRestClient bool) (Session, error) {
+	m.evictIdle(ctx)
+
+	s := m.lockSession(key)
+	defer s.mu.Unlock()
+
+	if s.GovmomiClient != nil && !s.govmomiActive(ctx) {
+		m.log.V(1).Info("cached vCenter session is no longer active", "host", key.Host, "username", key.Username)
+		s.logout(ctx)
+	}
+
+	if s.GovmomiClient == nil {
+		govClient, err := m.createGovmomiClientWithKeepAlive(ctx, s, account)
+		if err != nil {
+			m.remove(key, s)
+			return Session{}, err
+		}
+		s.GovmomiClient = govClient
+		s.RestClient = nil
+	}
+
+	if s.RestClient == nil || (refreshRestClient && !s.restActive(ctx)) {
+		restClient, err := createRestClientWithKeepAlive(ctx, account, s.GovmomiClient)
+		if err != nil {
+			return Session{}, err
+		}
+		s.RestClient = restClient
+	}
+
+	s.lastUsed = m.now()
+	return s.Session, nil
+}
+
+// lockSession returns the cached session for the given key, caching a new one if none exists, with its lock held.
+// A session that was removed from the cache while waiting for its lock, e.g., because another caller failed to log
+// in, is never returned, since it would be orphaned.
+func (m *SessionManager) lockSession(key SessionKey) *cachedSession {
+	for {
+		m.mu.Lock()
+		s, ok := m.sessions[key]
+		if !ok {
+			s = &cachedSession{key: key}
+			m.sessions[key] = s
+		}
+		m.mu.Unlock()
+
+		s.mu.Lock()
+		m.mu.Lock()
+		cached := m.sessions[key] == s
+		m.mu.Unlock()
+		if cached {
+			return s
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Acquire returns the clients of the session for the given account, as GetOrCreate does, and records a reference
+// to the session on behalf of owner. If owner previously referenced a different session, e.g., because its
+// credentials changed, that reference is released.
+func (m *SessionManager) Acquire(ctx context.Context, owner string, account vcenter.Account) (Session, error) {
+	key, err := NewSessionKey(account)
+	if err != nil {
+		return Session{}, err
+	}
+	session, err := m.getOrCreate(ctx, key, account, true)
+	if err != nil {
+		return Session{}, err
+	}
+
+	m.mu.Lock()
+	prev, ok := m.owners[owner]
+	m.owners[owner] = key
+	m.mu.Unlock()
+
+	if ok && prev != key {
+		m.releaseKey(ctx, prev)
+	}
+	return session, nil
+}
+
+// Release releases owner's reference to its session. The session is logged out and evicted if no other owner references it.
+func (m *SessionManager) Release(ctx context.Context, owner string) {
+	m.mu.Lock()
+	key, ok := m.owners[owner]
+	delete(m.owners, owner)
+	m.mu.Unlock()
+
+	if ok {
+		m.releaseKey(ctx, key)
+	}
+}
+
+// Close logs out of and evicts all cached sessions, regardless of their owners.
+func (m *SessionManager) Close(ctx context.Context) {
+	m.mu.Lock()
+	sessions := m.sessions
+	m.sessions = make(map[SessionKey]*cachedSession)
+	m.owners = make(map[string]SessionKey)
+	m.mu.Unlock()
+
+	for _, s := range sessions {
+		s.mu.Lock()
+		s.logout(ctx)
+		s.mu.Unlock()
+	}
+}
+
+// releaseKey evicts the session for the given key if it is no longer referenced by any owner.
+func (m *SessionManager) releaseKey(ctx context.Context, key SessionKey) {
+	m.mu.Lock()
+	s, ok := m.sessions[key]
+	release := ok && !m.referenced(key)
+	if release {
+		delete(m.sessions, key)
+	}
+	m.mu.Unlock()
+
+	if !release {
+		return
+	}
+	m.log.V(1).Info("releasing unreferenced vCenter session", "host", key.Host, "username", key.Username)
+	s.mu.Lock()
+	s.logout(ctx)
+	s.mu.Unlock()
+}
+
+// referenced reports whether any owner references the session for the given key. The caller must hold m.mu.
+func (m *SessionManager) referenced(key SessionKey) bool {
+	for _, k := range m.owners {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Evict logs out of and removes the session for the given key, if one is cached.
+func (m *SessionManager) Evict(ctx context.Context, key SessionKey) {
+	m.mu.Lock()
+	s, ok := m.sessions[key]
+	delete(m.sessions, key)
+	m.mu.Unlock()
+
+	if ok {
+		s.mu.Lock()
+		s.logout(ctx)
+		s.mu.Unlock()
+	}
+}
+
+// Len returns the number of cached sessions.
+func (m *SessionManager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.sessions)
+}
+
+// evictIdle logs out of and removes all unreferenced sessions that have been unused for longer than the idle timeout.
+func (m *SessionManager) evictIdle(ctx context.Context) {
+	if m.idleTimeout <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	expired := make([]*cachedSession, 0)
+	for k, s := range m.sessions {
+		if m.referenced(k) {
+			continue
+		}
+		if s.mu.TryLock() {
+			if !s.lastUsed.IsZero() && m.now().Sub(s.lastUsed) > m.idleTimeout {
+				expired = append(expired, s)
+				delete(m.sessions, k)
+			}
+			s.mu.Unlock()
+		}
+	}
+	m.mu.Unlock()
+
+	for _, s := range expired {
+		m.log.V(1).Info("evicting idle vCenter session", "host", s.key.Host, "username", s.key.Username)
+		s.mu.Lock()
+		s.logout(ctx)
+		s.mu.Unlock()
+	}
+}
+
+// remove deletes the session for the given key from the cache without logging out, provided it is still the cached session.
+func (m *SessionManager) remove(key SessionKey, s *cachedSession) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sessions[key] == s {
+		delete(m.sessions, key)
+	}
+}
+
+func (s *cachedSession) govmomiActive(ctx context.Context) bool {
+	active, err := s.GovmomiClient.SessionManager.SessionIsActive(ctx)
+	if err == nil {
+		return active
+	}
+	// SessionIsActive requires the Sessions.ValidateSession privilege, so fall back to
+	// checking whether the current session can be retrieved.
+	userSession, err := s.GovmomiClient.SessionManager.UserSession(ctx)
+	return err == nil && userSession != nil
+}
+
+func (s *cachedSession) restActive(ctx context.Context) bool {
+	restSession, err := s.RestClient.Session(ctx)
+	return err == nil && restSession != nil
+}
+
+// logout logs out of the session's REST and govmomi clients and clears them. The caller must hold s.mu.
+func (s *cachedSession) logout(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, sessionLogoutTimeout)
+	defer cancel()
+
+	if s.RestClient != nil {
+		_ = s.RestClient.Logout(ctx)
+		s.RestClient = nil
+	}
+	if s.GovmomiClient != nil {
+		_ = s.GovmomiClient.Logout(ctx)
+		s.GovmomiClient = nil
+	}
+}
+
+func (m *SessionManager) createGovmomiClientWithKeepAlive(ctx context.Context, s *cachedSession, account vcenter.Account) (*govmomi.Client, error) {
+	// get vcenter URL
+	vCenterURL, err := getVCenterURL(account)
+	if err != nil {
+		return nil, err
+	}
+
+	insecure := true
+
+	soapClient := soap.NewClient(vCenterURL, insecure)
+	vimClient, err := vim25.NewClient(ctx, soapClient)
+	if err != nil {
+		return nil, err
+	}
+
+	vimClient.UserAgent = "vsphere-validator"
+
+	c := &govmomi.Client{
+		Client:         vimClient,
+		SessionManager: session.NewManager(vimClient),
+	}
+
+	send := func() error {
+		ctx := context.Background()
+		_, err := methods.GetCurrentTime(ctx, vimClient.RoundTripper)
+		if err != nil {
+			// the session is already unusable, so drop it from the cache without logging out
+			m.remove(s.key, s)
+		}
+		return err
+	}
+
+	// this starts the keep alive handler when Login is called, and stops the handler when Logout is called
+	// it'll also stop the handler when send() returns error, so we wrap around the default send()
+	// with err check to evict the session in case of error
+	vimClient.RoundTripper = keepalive.NewHandlerSOAP(vimClient.RoundTripper, KeepAliveIntervalInMinute*time.Minute, send)
+
+	if err := login(ctx, c, account); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// createRestClientWithKeepAlive creates a REST client for operations like get tags
+func createRestClientWithKeepAlive(ctx context.Context, account vcenter.Account, govClient *govmomi.Client) (*rest.Client, error) {
+	restClient := rest.NewClient(govClient.Client)
+
+	return restClient, loginRest(ctx, restClient, govClient.Client, account)
+}
@@ -0,0 +1,201 @@
+package vsphere
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/vim25/methods"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+)
+
+func TestNewSessionKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		a         vcenter.Account
+		b         vcenter.Account
+		wantEqual bool
+	}{
+		{
+			name:      "Host and username do not collide when concatenated",
+			a:         vcenter.Account{Host: "a.b", Username: "c", Password: "p"},
+			b:         vcenter.Account{Host: "a", Username: ".bc", Password: "p"},
+			wantEqual: false,
+		},
+		{
+			name:      "Different passwords yield different keys",
+			a:         vcenter.Account{Host: "vcenter.example.com", Username: "admin", Password: "p1"},
+			b:         vcenter.Account{Host: "vcenter.example.com", Username: "admin", Password: "p2"},
+			wantEqual: false,
+		},
+		{
+			name:      "Different tokens yield different keys",
+			a:         vcenter.Account{Host: "vcenter.example.com", Token: "t1"},
+			b:         vcenter.Account{Host: "vcenter.example.com", Token: "t2"},
+			wantEqual: false,
+		},
+		{
+			name:      "Credentials do not collide when concatenated",
+			a:         vcenter.Account{Host: "vcenter.example.com", Password: "ab", Token: "c"},
+			b:         vcenter.Account{Host: "vcenter.example.com", Password: "a", Token: "bc"},
+			wantEqual: false,
+		},
+		{
+			name:      "Different ports yield different keys",
+			a:         vcenter.Account{Host: "vcenter.example.com:8443", Username: "admin", Password: "p"},
+			b:         vcenter.Account{Host: "vcenter.example.com:9443", Username: "admin", Password: "p"},
+			wantEqual: false,
+		},
+		{
+			name:      "Default port and scheme are normalized",
+			a:         vcenter.Account{Host: "https://vcenter.example.com/", Username: "admin", Password: "p"},
+			b:         vcenter.Account{Host: "vcenter.example.com:443", Username: "admin", Password: "p"},
+			wantEqual: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewSessionKey(tt.a)
+			assert.NoError(t, err)
+			b, err := NewSessionKey(tt.b)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantEqual, a == b)
+		})
+	}
+}
+
+func TestSessionManager(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8458, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	ctx := context.Background()
+	now := time.Now()
+	m := NewSessionManager(time.Minute, logr.Discard())
+	m.now = func() time.Time { return now }
+
+	s1, err := m.GetOrCreate(ctx, vcSim.Account, true)
+	assert.NoError(t, err)
+	s2, err := m.GetOrCreate(ctx, vcSim.Account, true)
+	assert.NoError(t, err)
+	assert.Same(t, s1.GovmomiClient, s2.GovmomiClient, "expected cached session to be reused")
+	assert.Equal(t, 1, m.Len())
+
+	// a session that is no longer active is replaced
+	client := s1.GovmomiClient
+	assert.NoError(t, client.Logout(ctx))
+	s3, err := m.GetOrCreate(ctx, vcSim.Account, true)
+	assert.NoError(t, err)
+	assert.NotSame(t, client, s3.GovmomiClient, "expected inactive govmomi client to be replaced")
+
+	// idle sessions are evicted
+	now = now.Add(2 * time.Minute)
+	m.evictIdle(ctx)
+	assert.Equal(t, 0, m.Len())
+
+	// explicit eviction removes the session
+	_, err = m.GetOrCreate(ctx, vcSim.Account, true)
+	assert.NoError(t, err)
+	key, err := NewSessionKey(vcSim.Account)
+	assert.NoError(t, err)
+	m.Evict(ctx, key)
+	assert.Equal(t, 0, m.Len())
+
+	// failed logins are not cached
+	badAccount := vcSim.Account
+	badAccount.Password = "wrong"
+	_, err = m.GetOrCreate(ctx, badAccount, true)
+	assert.Error(t, err)
+	assert.Equal(t, 0, m.Len())
+
+	// a session removed from the cache while waiting for its lock, e.g., after another caller's failed login,
+	// is not returned
+	orphan := &cachedSession{key: key}
+	m.sessions[key] = orphan
+	orphan.mu.Lock()
+	result := make(chan Session)
+	go func() {
+		s, err := m.GetOrCreate(ctx, vcSim.Account, true)
+		assert.NoError(t, err)
+		result <- s
+	}()
+	time.Sleep(100 * time.Millisecond)
+	m.remove(key, orphan)
+	orphan.mu.Unlock()
+	s4 := <-result
+	assert.NotNil(t, s4.GovmomiClient)
+	assert.Same(t, s4.GovmomiClient, m.sessions[key].GovmomiClient, "expected returned session to be cached")
+	assert.Nil(t, orphan.GovmomiClient, "expected orphaned session not to be used")
+
+	// the clients returned remain safe to use while the session is concurrently logged out and replaced
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s, err := m.GetOrCreate(ctx, vcSim.Account, false)
+			if assert.NoError(t, err) {
+				_, _ = methods.GetCurrentTime(ctx, s.GovmomiClient.Client)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			m.Evict(ctx, key)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSessionManagerReferenceCounting(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8459, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	ctx := context.Background()
+	m := NewSessionManager(time.Minute, logr.Discard())
+
+	s1, err := m.Acquire(ctx, "ns/validator-1", vcSim.Account)
+	assert.NoError(t, err)
+	s2, err := m.Acquire(ctx, "ns/validator-2", vcSim.Account)
+	assert.NoError(t, err)
+	assert.Same(t, s1.GovmomiClient, s2.GovmomiClient, "expected owners with the same credentials to share a session")
+
+	// the session remains cached while any owner references it
+	m.Release(ctx, "ns/validator-1")
+	assert.Equal(t, 1, m.Len())
+	assertLoggedIn(t, s1, true)
+
+	// referenced sessions are not evicted when idle
+	m.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	m.evictIdle(ctx)
+	assert.Equal(t, 1, m.Len())
+
+	// releasing the last owner logs out
+	m.Release(ctx, "ns/validator-2")
+	assert.Equal(t, 0, m.Len())
+	assertLoggedIn(t, s1, false)
+
+	// releasing an unknown owner is a no-op
+	m.Release(ctx, "ns/validator-3")
+
+	// close logs out of all sessions
+	s3, err := m.Acquire(ctx, "ns/validator-1", vcSim.Account)
+	assert.NoError(t, err)
+	m.Close(ctx)
+	assert.Equal(t, 0, m.Len())
+	assertLoggedIn(t, s3, false)
+}
+
+// assertLoggedIn asserts whether a session's govmomi client is logged in
+func assertLoggedIn(t *testing.T, s Session, expected bool) {
+	t.Helper()
+	us, err := s.GovmomiClient.SessionManager.UserSession(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, expected, us != nil)
+}
@@ -0,0 +1,116 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/pbm"
+	"github.com/vmware/govmomi/vapi/namespace"
+	"github.com/vmware/govmomi/vapi/rest"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+// namespacesPath is the vAPI endpoint for vSphere Namespaces
+const namespacesPath = "/api/vcenter/namespaces/instances"
+
+// namespaceInfo is a vSphere Namespace as returned by the vAPI, including its resource quotas,
+// which are not part of namespace.NamespacesInstanceInfo
+type namespaceInfo struct {
+	namespace.NamespacesInstanceInfo
+	ResourceSpec struct {
+		CPULimit            int64 `json:"cpu_limit"`
+		MemoryLimit         int64 `json:"memory_limit"`
+		StorageRequestLimit int64 `json:"storage_request_limit"`
+	} `json:"resource_spec"`
+}
+
+// GetSupervisorCluster returns whether Workload Management is enabled on a cluster and, if so, the status of its Supervisor
+func (v *VCenterDriver) GetSupervisorCluster(ctx context.Context, finder *find.Finder, datacenter, clusterName string) (*vcenter.SupervisorCluster, error) {
+	cluster, err := v.GetCluster(ctx, finder, datacenter, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := namespace.NewManager(v.RestClient).ListClusters(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list Workload Management clusters")
+	}
+
+	clusterID := cluster.Reference().Value
+	for _, s := range summaries {
+		if s.ID != clusterID {
+			continue
+		}
+		sc := &vcenter.SupervisorCluster{ClusterID: clusterID, Enabled: true}
+		if s.ConfigStatus != nil {
+			sc.ConfigStatus = s.ConfigStatus.String()
+		}
+		if s.KubernetesStatus != nil {
+			sc.KubernetesStatus = s.KubernetesStatus.String()
+		}
+		return sc, nil
+	}
+
+	return &vcenter.SupervisorCluster{ClusterID: clusterID}, nil
+}
+
+// GetNamespace returns a vSphere Namespace, or nil if it does not exist
+func (v *VCenterDriver) GetNamespace(ctx context.Context, name string) (*vcenter.Namespace, error) {
+	var info namespaceInfo
+	req := v.RestClient.Resource(namespacesPath).WithSubpath(name).Request(http.MethodGet)
+	if err := v.RestClient.Do(ctx, req, &info); err != nil {
+		if rest.IsStatusError(err, http.StatusNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to get namespace %s", name))
+	}
+
+	ns := &vcenter.Namespace{
+		Name:            name,
+		ClusterID:       info.ClusterId,
+		ConfigStatus:    info.ConfigStatus,
+		StoragePolicies: make([]string, 0, len(info.StorageSpecs)),
+		VMClasses:       info.VmServiceSpec.VmClasses,
+		CPULimitMHz:     info.ResourceSpec.CPULimit,
+		CPUUsedMHz:      info.Stats.CpuUsed,
+		MemoryLimitMiB:  info.ResourceSpec.MemoryLimit,
+		MemoryUsedMiB:   info.Stats.MemoryUsed,
+		StorageLimitMiB: info.ResourceSpec.StorageRequestLimit,
+		StorageUsedMiB:  info.Stats.StorageUsed,
+	}
+	if ns.VMClasses == nil {
+		ns.VMClasses = make([]string, 0)
+	}
+
+	if len(info.StorageSpecs) > 0 {
+		// storage specs reference storage policies by ID
+		c, err := pbm.NewClient(ctx, v.Client.Client)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create storage policy client")
+		}
+		ids := make([]string, 0, len(info.StorageSpecs))
+		for _, spec := range info.StorageSpecs {
+			ids = append(ids, spec.Policy)
+		}
+		profiles, err := c.ProfileMap(ctx, ids...)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to get storage policies of namespace %s", name))
+		}
+		for _, id := range ids {
+			policyName := id
+			if p, ok := profiles.Name[id]; ok {
+				policyName = p.GetPbmProfile().Name
+			}
+			ns.StoragePolicies = append(ns.StoragePolicies, policyName)
+		}
+	}
+	sort.Strings(ns.StoragePolicies)
+	sort.Strings(ns.VMClasses)
+
+	return ns, nil
+}
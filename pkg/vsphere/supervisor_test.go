@@ -0,0 +1,58 @@
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/vapi/namespace"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+)
+
+func TestGetSupervisor(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8470, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc, err := driver.GetSupervisorCluster(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, sc.ClusterID)
+	assert.False(t, sc.Enabled)
+
+	ns, err := driver.GetNamespace(ctx, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, ns)
+
+	err = namespace.NewManager(driver.RestClient).CreateNamespace(ctx, namespace.NamespacesInstanceCreateSpec{
+		Cluster:       "domain-c1",
+		Namespace:     "tkg",
+		VmServiceSpec: namespace.VmServiceSpec{VmClasses: []string{"best-effort-small", "best-effort-large"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns, err = driver.GetNamespace(ctx, "tkg")
+	assert.NoError(t, err)
+	assert.Equal(t, &vcenter.Namespace{
+		Name:            "tkg",
+		ClusterID:       "domain-c1",
+		ConfigStatus:    "RUNNING",
+		StoragePolicies: []string{},
+		VMClasses:       []string{"best-effort-large", "best-effort-small"},
+	}, ns)
+}
@@ -44,6 +44,11 @@ func (v *VCenterDriver) getTagsAndCategory(ctx context.Context, client *vim25.Cl
 }
 
 func (v *VCenterDriver) getTagManager(ctx context.Context, client *vim25.Client) (*tags.Manager, error) {
+	// reuse the session's REST client rather than logging in again
+	if v.RestClient != nil {
+		return tags.NewManager(v.RestClient), nil
+	}
+
 	c := rest.NewClient(client)
 	err := c.Login(ctx, v.Account.Userinfo())
 	if err != nil {
@@ -0,0 +1,213 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter/entity"
+)
+
+// GetTopology returns the region tags attached to each of the given datacenters and the zone tags attached
+// to each cluster or host system within them. If no datacenters are given, all datacenters are returned.
+func (v *VCenterDriver) GetTopology(ctx context.Context, datacenters []string, regionCategory, zoneCategory string, zoneEntityType entity.Entity) ([]vcenter.TopologyDatacenter, error) {
+	var zoneType string
+	switch zoneEntityType {
+	case entity.Cluster:
+		zoneType = "ClusterComputeResource"
+	case entity.Host:
+		zoneType = "HostSystem"
+	default:
+		return nil, fmt.Errorf("unsupported zone entity type: %s", zoneEntityType)
+	}
+
+	if len(datacenters) == 0 {
+		var err error
+		datacenters, err = v.GetDatacenters(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tm := tags.NewManager(v.RestClient)
+	categoryIDs, err := v.getCategoryIDs(ctx, tm, regionCategory, zoneCategory)
+	if err != nil {
+		return nil, err
+	}
+
+	finder, err := v.getFinder()
+	if err != nil {
+		return nil, err
+	}
+	pc := property.DefaultCollector(v.Client.Client)
+
+	topology := make([]vcenter.TopologyDatacenter, 0, len(datacenters))
+	for _, name := range datacenters {
+		dc, err := finder.Datacenter(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get datacenter %s: %w", name, err)
+		}
+
+		entities, err := v.retrieveZoneEntities(ctx, dc.Reference(), zoneType)
+		if err != nil {
+			return nil, err
+		}
+
+		refs := []mo.Reference{dc.Reference()}
+		for _, e := range entities {
+			refs = append(refs, e.ref)
+		}
+		attached, err := tm.GetAttachedTagsOnObjects(ctx, refs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tags attached to inventory of datacenter %s: %w", name, err)
+		}
+		tagsByRef := make(map[string][]tags.Tag, len(attached))
+		for _, a := range attached {
+			tagsByRef[a.ObjectID.Reference().Value] = a.Tags
+		}
+
+		td := vcenter.TopologyDatacenter{
+			Name:    name,
+			Regions: tagNamesInCategory(tagsByRef[dc.Reference().Value], categoryIDs[regionCategory]),
+		}
+		for _, e := range entities {
+			datastores, err := sharedDatastoreNames(ctx, pc, e.datastore)
+			if err != nil {
+				return nil, err
+			}
+			networks, err := managedEntityNames(ctx, pc, e.network)
+			if err != nil {
+				return nil, err
+			}
+			td.Entities = append(td.Entities, vcenter.TopologyEntity{
+				Name:             e.name,
+				Zones:            tagNamesInCategory(tagsByRef[e.ref.Value], categoryIDs[zoneCategory]),
+				SharedDatastores: datastores,
+				Networks:         networks,
+			})
+		}
+		topology = append(topology, td)
+	}
+
+	return topology, nil
+}
+
+// zoneEntity is the subset of cluster or host system properties required for topology validation.
+type zoneEntity struct {
+	ref       types.ManagedObjectReference
+	name      string
+	datastore []types.ManagedObjectReference
+	network   []types.ManagedObjectReference
+}
+
+// retrieveZoneEntities retrieves the name, datastores and networks of all clusters or host systems beneath a datacenter.
+func (v *VCenterDriver) retrieveZoneEntities(ctx context.Context, dc types.ManagedObjectReference, kind string) ([]zoneEntity, error) {
+	m := view.NewManager(v.Client.Client)
+	cv, err := m.CreateContainerView(ctx, dc, []string{kind}, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container view for %s: %w", kind, err)
+	}
+	defer func() {
+		_ = cv.Destroy(ctx)
+	}()
+
+	ps := []string{"name", "datastore", "network"}
+	entities := make([]zoneEntity, 0)
+	switch kind {
+	case "ClusterComputeResource":
+		var ccrs []mo.ClusterComputeResource
+		if err := cv.Retrieve(ctx, []string{kind}, ps, &ccrs); err != nil {
+			return nil, fmt.Errorf("failed to retrieve clusters: %w", err)
+		}
+		for _, c := range ccrs {
+			entities = append(entities, zoneEntity{ref: c.Reference(), name: c.Name, datastore: c.Datastore, network: c.Network})
+		}
+	case "HostSystem":
+		var hss []mo.HostSystem
+		if err := cv.Retrieve(ctx, []string{kind}, ps, &hss); err != nil {
+			return nil, fmt.Errorf("failed to retrieve host systems: %w", err)
+		}
+		for _, h := range hss {
+			entities = append(entities, zoneEntity{ref: h.Reference(), name: h.Name, datastore: h.Datastore, network: h.Network})
+		}
+	}
+	sort.Slice(entities, func(i, j int) bool { return entities[i].name < entities[j].name })
+
+	return entities, nil
+}
+
+func (v *VCenterDriver) getCategoryIDs(ctx context.Context, tm *tags.Manager, names ...string) (map[string]string, error) {
+	categories, err := tm.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag categories: %w", err)
+	}
+	ids := make(map[string]string, len(names))
+	for _, c := range categories {
+		for _, n := range names {
+			if c.Name == n {
+				ids[n] = c.ID
+			}
+		}
+	}
+	for _, n := range names {
+		if _, ok := ids[n]; !ok {
+			return nil, fmt.Errorf("tag category %s not found", n)
+		}
+	}
+	return ids, nil
+}
+
+func tagNamesInCategory(ts []tags.Tag, categoryID string) []string {
+	names := make([]string, 0)
+	for _, t := range ts {
+		if t.CategoryID == categoryID {
+			names = append(names, t.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sharedDatastoreNames(ctx context.Context, pc *property.Collector, refs []types.ManagedObjectReference) ([]string, error) {
+	names := make([]string, 0)
+	if len(refs) == 0 {
+		return names, nil
+	}
+	var datastores []mo.Datastore
+	if err := pc.Retrieve(ctx, refs, []string{"summary"}, &datastores); err != nil {
+		return nil, fmt.Errorf("failed to retrieve datastores: %w", err)
+	}
+	for _, ds := range datastores {
+		// skip host local storage
+		shared := ds.Summary.MultipleHostAccess
+		if shared != nil && !*shared {
+			continue
+		}
+		names = append(names, ds.Summary.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func managedEntityNames(ctx context.Context, pc *property.Collector, refs []types.ManagedObjectReference) ([]string, error) {
+	names := make([]string, 0)
+	if len(refs) == 0 {
+		return names, nil
+	}
+	var entities []mo.ManagedEntity
+	if err := pc.Retrieve(ctx, refs, []string{"name"}, &entities); err != nil {
+		return nil, fmt.Errorf("failed to retrieve managed entities: %w", err)
+	}
+	for _, e := range entities {
+		names = append(names, e.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -108,6 +108,15 @@ func (v *VCenterDriver) ValidateUserPrivilegeOnEntities(ctx context.Context, aut
 	return failures, nil
 }
 
+// GetPrivilegeRuleEntity returns the reference of a privilege validation rule's entity.
+func (v *VCenterDriver) GetPrivilegeRuleEntity(ctx context.Context, datacenter string, finder *find.Finder, rule v1alpha1.PrivilegeValidationRule) (types.ManagedObjectReference, error) {
+	objRef, err := v.getObjRef(ctx, datacenter, finder, rule)
+	if err != nil {
+		return types.ManagedObjectReference{}, err
+	}
+	return *objRef, nil
+}
+
 func (v *VCenterDriver) getObjRef(ctx context.Context, datacenter string, finder *find.Finder, rule v1alpha1.PrivilegeValidationRule) (*types.ManagedObjectReference, error) {
 	var objRef types.ManagedObjectReference
 
@@ -20,6 +20,13 @@ import (
 
 // GetVM returns the vCenter VM if it exists
 func (v *VCenterDriver) GetVM(ctx context.Context, finder *find.Finder, vmName string) (*object.VirtualMachine, error) {
+	if p, ok := v.datacenterPath("vm", vmName); ok {
+		if obj, ok := v.cachedObject(p); ok {
+			if vm, ok := obj.(*object.VirtualMachine); ok {
+				return vm, nil
+			}
+		}
+	}
 	vm, err := finder.VirtualMachine(ctx, vmName)
 	if err != nil {
 		return nil, err
@@ -42,6 +49,48 @@ func (v *VCenterDriver) GetVMs(ctx context.Context, datacenter string) ([]vcente
 	return v.getVMInfo(ctx, finder, client, datacenter, v1, vms)
 }
 
+// GetVMIPAddresses returns the names and guest IP addresses of all vCenter VMs in a datacenter
+func (v *VCenterDriver) GetVMIPAddresses(ctx context.Context, datacenter string) ([]vcenter.VM, error) {
+	finder, _, err := v.GetFinderWithDatacenter(ctx, datacenter)
+	if err != nil {
+		return nil, err
+	}
+
+	vmObjs, err := finder.VirtualMachineList(ctx, "*")
+	if err != nil {
+		var notFoundErr *find.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return []vcenter.VM{}, nil
+		}
+		return nil, errors.Wrap(err, "failed to fetch vSphere vms")
+	}
+
+	refs := make([]types.ManagedObjectReference, 0, len(vmObjs))
+	for _, vm := range vmObjs {
+		refs = append(refs, vm.Reference())
+	}
+
+	var mvms []mo.VirtualMachine
+	pc := property.DefaultCollector(v.Client.Client)
+	if err := pc.Retrieve(ctx, refs, []string{"name", "guest"}, &mvms); err != nil {
+		return nil, errors.Wrap(err, "failed to get virtual machine guest info")
+	}
+
+	vms := make([]vcenter.VM, 0, len(mvms))
+	for _, mvm := range mvms {
+		vm := vcenter.VM{
+			Name:    mvm.Name,
+			Network: getNetworks(mvm),
+		}
+		if mvm.Guest != nil {
+			vm.IPAddress = mvm.Guest.IpAddress
+		}
+		vms = append(vms, vm)
+	}
+
+	return vms, nil
+}
+
 func (v *VCenterDriver) getVMClient(ctx context.Context, datacenter string) (*find.Finder, *view.ContainerView, *vim25.Client, error) {
 	finder, _, err := v.GetFinderWithDatacenter(ctx, datacenter)
 	if err != nil {
@@ -1,8 +1,10 @@
 package vsphere
 
 import (
+	"context"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/performance"
@@ -10,6 +12,7 @@ import (
 	"github.com/vmware/govmomi/vim25/types"
 
 	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
 )
 
 func TestToVSphereVMs(t *testing.T) {
@@ -172,3 +175,24 @@ func TestToVSphereVMs(t *testing.T) {
 		})
 	}
 }
+
+func TestGetVMIPAddresses(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8463, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vms, err := driver.GetVMIPAddresses(context.Background(), vcSim.Options.Datacenter)
+	assert.NoError(t, err)
+
+	names := make([]string, 0, len(vms))
+	for _, vm := range vms {
+		names = append(names, vm.Name)
+		assert.Empty(t, vm.IPAddress, "expected simulated VM %s to have no guest IP address", vm.Name)
+	}
+	assert.ElementsMatch(t, []string{"DC0_H0_VM0", "DC0_C0_RP1_VM0", "DC0_C0_RP2_VM0", "DC0_C1_RP1_VM0", "DC0_C1_RP2_VM0"}, names)
+}
@@ -0,0 +1,209 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/pbm"
+	pbmtypes "github.com/vmware/govmomi/pbm/types"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/govmomi/vsan"
+	vsanmethods "github.com/vmware/govmomi/vsan/methods"
+	vsantypes "github.com/vmware/govmomi/vsan/types"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+)
+
+const (
+	vsanDiskStateInUse = "inUse"
+
+	pbmVSANNamespace           = "VSAN"
+	pbmFailuresToTolerate      = "hostFailuresToTolerate"
+	pbmReplicaPreference       = "replicaPreference"
+	pbmErasureCodingPreference = "RAID-5/6"
+)
+
+var (
+	// vsanClusterHealthSystem is the vSAN health service's cluster health system
+	vsanClusterHealthSystem = types.ManagedObjectReference{
+		Type:  "VsanVcClusterHealthSystem",
+		Value: "vsan-cluster-health-system",
+	}
+
+	// vsanSpaceReportSystem is the vSAN health service's space report system
+	vsanSpaceReportSystem = types.ManagedObjectReference{
+		Type:  "VsanSpaceReportSystem",
+		Value: "vsan-cluster-space-report-system",
+	}
+)
+
+// GetVSANClusterInfo returns whether vSAN is enabled on a cluster and, if so, its health, capacity,
+// and the number of disks each host contributes
+func (v *VCenterDriver) GetVSANClusterInfo(ctx context.Context, finder *find.Finder, datacenter, clusterName string) (*vcenter.VSANClusterInfo, error) {
+	cluster, err := v.GetCluster(ctx, finder, datacenter, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := cluster.Configuration(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to get configuration of cluster %s", clusterName))
+	}
+	info := &vcenter.VSANClusterInfo{
+		Enabled: cfg.VsanConfigInfo != nil && cfg.VsanConfigInfo.Enabled != nil && *cfg.VsanConfigInfo.Enabled,
+	}
+	if !info.Enabled {
+		return info, nil
+	}
+
+	vsanClient, err := vsan.NewClient(ctx, v.Client.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create vSAN client")
+	}
+	clusterRef := cluster.Reference()
+
+	health, err := vsanmethods.VsanQueryVcClusterHealthSummary(ctx, vsanClient, &vsantypes.VsanQueryVcClusterHealthSummary{
+		This:            vsanClusterHealthSystem,
+		Cluster:         &clusterRef,
+		IncludeObjUuids: types.NewBool(false),
+		FetchFromCache:  types.NewBool(true),
+		Fields:          []string{"groups", "overallHealth", "overallHealthDescription"},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to get vSAN health summary of cluster %s", clusterName))
+	}
+	info.OverallHealth = health.Returnval.OverallHealth
+	info.OverallHealthDescription = health.Returnval.OverallHealthDescription
+	for _, g := range health.Returnval.Groups {
+		for _, t := range g.GroupTests {
+			info.HealthTests = append(info.HealthTests, vcenter.VSANHealthTest{
+				Group:  g.GroupName,
+				Name:   t.TestName,
+				Health: t.TestHealth,
+			})
+		}
+	}
+
+	space, err := vsanmethods.VsanQuerySpaceUsage(ctx, vsanClient, &vsantypes.VsanQuerySpaceUsage{
+		This:    vsanSpaceReportSystem,
+		Cluster: clusterRef,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to get vSAN space usage of cluster %s", clusterName))
+	}
+	info.TotalCapacityBytes = space.Returnval.TotalCapacityB
+	info.FreeCapacityBytes = space.Returnval.FreeCapacityB
+
+	info.HostDisksInUse, err = v.getVSANHostDisksInUse(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// getVSANHostDisksInUse returns the number of disks claimed by vSAN on each host in a cluster
+func (v *VCenterDriver) getVSANHostDisksInUse(ctx context.Context, cluster *object.ClusterComputeResource) (map[string]int, error) {
+	hosts, err := cluster.Hosts(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cluster hosts")
+	}
+	refs := make([]types.ManagedObjectReference, 0, len(hosts))
+	for _, h := range hosts {
+		refs = append(refs, h.Reference())
+	}
+
+	var mhosts []mo.HostSystem
+	if err := v.Client.Retrieve(ctx, refs, []string{"name", "configManager.vsanSystem"}, &mhosts); err != nil {
+		return nil, errors.Wrap(err, "failed to get host vSAN systems")
+	}
+
+	disks := make(map[string]int, len(mhosts))
+	for _, h := range mhosts {
+		disks[h.Name] = 0
+		if h.ConfigManager.VsanSystem == nil {
+			continue
+		}
+		res, err := methods.QueryDisksForVsan(ctx, v.Client.Client, &types.QueryDisksForVsan{This: *h.ConfigManager.VsanSystem})
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to query vSAN disks of host %s", h.Name))
+		}
+		for _, d := range res.Returnval {
+			if d.State == vsanDiskStateInUse {
+				disks[h.Name]++
+			}
+		}
+	}
+
+	return disks, nil
+}
+
+// GetVSANStoragePolicy returns the failures to tolerate and fault tolerance method of a vSAN storage policy
+func (v *VCenterDriver) GetVSANStoragePolicy(ctx context.Context, name string) (*vcenter.VSANStoragePolicy, error) {
+	c, err := pbm.NewClient(ctx, v.Client.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create storage policy client")
+	}
+
+	id, err := c.ProfileIDByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	profiles, err := c.RetrieveContent(ctx, []pbmtypes.PbmProfileId{{UniqueId: id}})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to get storage policy %s", name))
+	}
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("storage policy %s not found", name)
+	}
+
+	return toVSANStoragePolicy(name, profiles[0])
+}
+
+func toVSANStoragePolicy(name string, profile pbmtypes.BasePbmProfile) (*vcenter.VSANStoragePolicy, error) {
+	policy := &vcenter.VSANStoragePolicy{Name: name}
+
+	capabilityProfile, ok := profile.(*pbmtypes.PbmCapabilityProfile)
+	if !ok {
+		return nil, fmt.Errorf("storage policy %s is not a capability-based policy", name)
+	}
+	constraints, ok := capabilityProfile.Constraints.(*pbmtypes.PbmCapabilitySubProfileConstraints)
+	if !ok {
+		return nil, fmt.Errorf("storage policy %s has no rule sets", name)
+	}
+
+	found := false
+	for _, sp := range constraints.SubProfiles {
+		for _, c := range sp.Capability {
+			if c.Id.Namespace != pbmVSANNamespace {
+				continue
+			}
+			found = true
+			for _, ci := range c.Constraint {
+				for _, p := range ci.PropertyInstance {
+					switch p.Id {
+					case pbmFailuresToTolerate:
+						if ftt, ok := p.Value.(int32); ok {
+							policy.FailuresToTolerate = ftt
+						}
+					case pbmReplicaPreference:
+						if pref, ok := p.Value.(string); ok {
+							policy.ErasureCoding = strings.Contains(pref, pbmErasureCodingPreference)
+						}
+					}
+				}
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("storage policy %s has no vSAN rules", name)
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,94 @@
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	pbmtypes "github.com/vmware/govmomi/pbm/types"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+)
+
+func TestGetVSAN(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8467, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := driver.GetVSANClusterInfo(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster)
+	assert.NoError(t, err)
+	assert.False(t, info.Enabled)
+
+	policy, err := driver.GetVSANStoragePolicy(ctx, vcenter.VSANDefaultStoragePolicyName)
+	assert.NoError(t, err)
+	assert.Equal(t, &vcenter.VSANStoragePolicy{Name: vcenter.VSANDefaultStoragePolicyName, FailuresToTolerate: 1}, policy)
+
+	_, err = driver.GetVSANStoragePolicy(ctx, "missing")
+	assert.Error(t, err)
+}
+
+func TestToVSANStoragePolicy(t *testing.T) {
+	capability := func(id string, value any) pbmtypes.PbmCapabilityInstance {
+		return pbmtypes.PbmCapabilityInstance{
+			Id: pbmtypes.PbmCapabilityMetadataUniqueId{Namespace: "VSAN", Id: id},
+			Constraint: []pbmtypes.PbmCapabilityConstraintInstance{
+				{PropertyInstance: []pbmtypes.PbmCapabilityPropertyInstance{{Id: id, Value: value}}},
+			},
+		}
+	}
+	profile := func(capabilities ...pbmtypes.PbmCapabilityInstance) pbmtypes.BasePbmProfile {
+		return &pbmtypes.PbmCapabilityProfile{
+			Constraints: &pbmtypes.PbmCapabilitySubProfileConstraints{
+				SubProfiles: []pbmtypes.PbmCapabilitySubProfile{{Capability: capabilities}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		profile     pbmtypes.BasePbmProfile
+		expected    *vcenter.VSANStoragePolicy
+		expectedErr string
+	}{
+		{
+			name:     "RAID-6 erasure coding",
+			profile:  profile(capability("hostFailuresToTolerate", int32(2)), capability("replicaPreference", "RAID-5/6 (Erasure Coding) - Capacity")),
+			expected: &vcenter.VSANStoragePolicy{Name: "policy", FailuresToTolerate: 2, ErasureCoding: true},
+		},
+		{
+			name:     "RAID-1 mirroring",
+			profile:  profile(capability("hostFailuresToTolerate", int32(1)), capability("replicaPreference", "RAID-1 (Mirroring) - Performance")),
+			expected: &vcenter.VSANStoragePolicy{Name: "policy", FailuresToTolerate: 1},
+		},
+		{
+			name:        "No vSAN rules",
+			profile:     profile(),
+			expectedErr: "storage policy policy has no vSAN rules",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := toVSANStoragePolicy("policy", tt.profile)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, policy)
+		})
+	}
+}
@@ -7,8 +7,6 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
-	"sync"
-	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-version"
@@ -16,14 +14,9 @@ import (
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
-	"github.com/vmware/govmomi/session"
-	"github.com/vmware/govmomi/session/keepalive"
 	"github.com/vmware/govmomi/vapi/rest"
 	"github.com/vmware/govmomi/vapi/tags"
-	"github.com/vmware/govmomi/vim25"
-	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
-	"github.com/vmware/govmomi/vim25/soap"
 
 	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
 )
@@ -39,12 +32,6 @@ const (
 	K8sComputeClusterTagCategory = "k8s-zone"
 )
 
-var (
-	sessionCache        = map[string]Session{}
-	sessionMU           sync.Mutex
-	restClientLoggedOut = false
-)
-
 // Driver is an interface that defines the functions to interact with vSphere
 type Driver interface {
 	GetClusters(ctx context.Context, datacenter string) ([]string, error)
@@ -76,12 +63,7 @@ type VCenterDriver struct {
 	Client     *govmomi.Client
 	RestClient *rest.Client
 	log        logr.Logger
-}
-
-// Session is a struct that contains the govmomi and rest clients
-type Session struct {
-	GovmomiClient *govmomi.Client
-	RestClient    *rest.Client
+	inventory  *Inventory
 }
 
 // NewVCenterDriver creates a new VCenterDriver
@@ -150,93 +132,6 @@ func (v *VCenterDriver) getFinder() (*find.Finder, error) {
 	return finder, nil
 }
 
-// GetOrCreateSession returns the session for the given server, username and password
-func GetOrCreateSession(ctx context.Context, account vcenter.Account, refreshRestClient bool) (Session, error) {
-	sessionMU.Lock()
-	defer sessionMU.Unlock()
-
-	sessionKey := account.Host + account.Username
-	currentSession, ok := sessionCache[sessionKey]
-
-	if ok {
-		if refreshRestClient && restClientLoggedOut {
-			restClient, err := createRestClientWithKeepAlive(ctx, account, currentSession.GovmomiClient)
-			if err != nil {
-				return currentSession, err
-			}
-			currentSession.RestClient = restClient
-			restClientLoggedOut = false
-		}
-		return currentSession, nil
-	}
-
-	// govmomi client
-	govClient, err := createGovmomiClientWithKeepAlive(ctx, sessionKey, account)
-	if err != nil {
-		return currentSession, err
-	}
-	currentSession.GovmomiClient = govClient
-
-	// REST client
-	restClient, err := createRestClientWithKeepAlive(ctx, account, govClient)
-	if err != nil {
-		return currentSession, err
-	}
-	currentSession.RestClient = restClient
-
-	// Cache the current session
-	sessionCache[sessionKey] = currentSession
-
-	return currentSession, nil
-}
-
-func createGovmomiClientWithKeepAlive(ctx context.Context, sessionKey string, account vcenter.Account) (*govmomi.Client, error) {
-	// get vcenter URL
-	vCenterURL, err := getVCenterURL(account)
-	if err != nil {
-		return nil, err
-	}
-
-	insecure := true
-
-	soapClient := soap.NewClient(vCenterURL, insecure)
-	vimClient, err := vim25.NewClient(ctx, soapClient)
-	if err != nil {
-		return nil, err
-	}
-
-	vimClient.UserAgent = "vsphere-validator"
-
-	c := &govmomi.Client{
-		Client:         vimClient,
-		SessionManager: session.NewManager(vimClient),
-	}
-
-	send := func() error {
-		ctx := context.Background()
-		_, err := methods.GetCurrentTime(ctx, vimClient.RoundTripper)
-		if err != nil {
-			ClearCache(sessionKey)
-		}
-		return err
-	}
-
-	// this starts the keep alive handler when Login is called, and stops the handler when Logout is called
-	// it'll also stop the handler when send() returns error, so we wrap around the default send()
-	// with err check to clear cache in case of error
-	vimClient.RoundTripper = keepalive.NewHandlerSOAP(vimClient.RoundTripper, KeepAliveIntervalInMinute*time.Minute, send)
-
-	// Only login if the URL contains user information.
-	if vCenterURL.User != nil {
-		err = c.Login(ctx, vCenterURL.User)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return c, nil
-}
-
 func getVCenterURL(account vcenter.Account) (*url.URL, error) {
 	// parse vCenter URL
 	for _, scheme := range []string{"http://", "https://"} {
@@ -253,17 +148,3 @@ func getVCenterURL(account vcenter.Account) (*url.URL, error) {
 
 	return vCenterURL, nil
 }
-
-// createRestClientWithKeepAlive creates a REST client for operations like get tags
-func createRestClientWithKeepAlive(ctx context.Context, account vcenter.Account, govClient *govmomi.Client) (*rest.Client, error) {
-	restClient := rest.NewClient(govClient.Client)
-
-	return restClient, restClient.Login(ctx, account.Userinfo())
-}
-
-// ClearCache deletes the session from the session cache
-func ClearCache(sessionKey string) {
-	sessionMU.Lock()
-	defer sessionMU.Unlock()
-	delete(sessionCache, sessionKey)
-}
@@ -0,0 +1,293 @@
+package vsphere
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/validator-labs/validator-plugin-vsphere/api/vcenter"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+)
+
+// DefaultWatchRetryInterval is the duration waited before re-establishing a failed inventory watch.
+const DefaultWatchRetryInterval = 30 * time.Second
+
+// watchedProperties are the properties whose changes trigger re-validation. Properties that change continuously,
+// e.g., resource usage and free space, are deliberately excluded.
+var watchedProperties = []types.PropertySpec{
+	{Type: "ManagedEntity", PathSet: []string{"name", "parent", "permission"}},
+	{Type: "ClusterComputeResource", PathSet: []string{"host", "datastore", "configurationEx"}},
+	{Type: "HostSystem", PathSet: []string{
+		"runtime.connectionState", "runtime.inMaintenanceMode", "config.network", "config.dateTimeInfo", "datastore", "network",
+	}},
+	{Type: "Datastore", PathSet: []string{"summary.accessible", "summary.capacity", "host"}},
+	{Type: "Network", PathSet: []string{"host"}},
+	{Type: "ResourcePool", PathSet: []string{"config"}},
+	{Type: "AuthorizationManager", PathSet: []string{"roleList"}},
+}
+
+// allValidationTypes are affected by changes to objects whose names appear in every inventory path
+var allValidationTypes = []string{
+	constants.ValidationTypePrivileges, constants.ValidationTypeTag, constants.ValidationTypeComputeResources,
+	constants.ValidationTypeNTP, constants.ValidationTypeTopology, constants.ValidationTypeHostDNS,
+	constants.ValidationTypeHostNetwork, constants.ValidationTypeIPPool, constants.ValidationTypeFolder,
+	constants.ValidationTypeResourcePool, constants.ValidationTypeDRSRule, constants.ValidationTypeVSAN,
+	constants.ValidationTypeCertificate, constants.ValidationTypeLicense, constants.ValidationTypeSupervisor,
+	constants.ValidationTypeNSXSegment,
+}
+
+// networkValidationTypes are affected by changes to networks
+var networkValidationTypes = []string{
+	constants.ValidationTypePrivileges, constants.ValidationTypeTag, constants.ValidationTypeHostNetwork,
+	constants.ValidationTypeIPPool, constants.ValidationTypeNSXSegment, constants.ValidationTypeSupervisor,
+}
+
+// WatchedTypeValidationTypes maps the managed object types that are watched to the validation types of the rules
+// their changes could affect. Virtual machines are deliberately not watched, since they change far more often than
+// anything that is validated.
+var WatchedTypeValidationTypes = map[string][]string{
+	"Datacenter": allValidationTypes,
+	"Folder": {
+		constants.ValidationTypePrivileges, constants.ValidationTypeTag, constants.ValidationTypeFolder,
+	},
+	"ClusterComputeResource": allValidationTypes,
+	"HostSystem": append([]string{
+		constants.ValidationTypePrivileges, constants.ValidationTypeTag, constants.ValidationTypeTopology,
+	}, hostValidationTypes...),
+	"Datastore": {
+		constants.ValidationTypePrivileges, constants.ValidationTypeTag, constants.ValidationTypeComputeResources,
+		constants.ValidationTypeVSAN,
+	},
+	"Network":                     networkValidationTypes,
+	"DistributedVirtualPortgroup": networkValidationTypes,
+	"OpaqueNetwork":               networkValidationTypes,
+	"ResourcePool": {
+		constants.ValidationTypePrivileges, constants.ValidationTypeTag, constants.ValidationTypeComputeResources,
+		constants.ValidationTypeResourcePool,
+	},
+	"AuthorizationManager": {constants.ValidationTypePrivileges},
+}
+
+// watchedViewTypes are the managed object types collected by an inventory watch's container view
+var watchedViewTypes = []string{"Datacenter", "Folder", "ClusterComputeResource", "HostSystem", "Datastore", "Network", "ResourcePool"}
+
+// inventoryOwner is an InventoryWatcher registration
+type inventoryOwner struct {
+	key             SessionKey
+	validationTypes []string
+}
+
+// InventoryWatcher maintains one long-lived property collector watch per vCenter session. Each watch is shared by
+// the owners that registered for it. Owners register for the validation types of their rules and are notified
+// whenever a watched property of an object that could affect them changes.
+type InventoryWatcher struct {
+	mu            sync.Mutex
+	watches       map[SessionKey]context.CancelFunc
+	owners        map[string]inventoryOwner
+	notify        func(owners []string)
+	sessions      *SessionManager
+	retryInterval time.Duration
+	log           logr.Logger
+}
+
+// NewInventoryWatcher creates a new InventoryWatcher. notify is called with the owners affected by changes to
+// the watched inventory.
+func NewInventoryWatcher(notify func(owners []string), log logr.Logger) *InventoryWatcher {
+	return newInventoryWatcher(defaultSessionManager, DefaultWatchRetryInterval, notify, log)
+}
+
+func newInventoryWatcher(sessions *SessionManager, retryInterval time.Duration, notify func(owners []string), log logr.Logger) *InventoryWatcher {
+	return &InventoryWatcher{
+		watches:       make(map[SessionKey]context.CancelFunc),
+		owners:        make(map[string]inventoryOwner),
+		notify:        notify,
+		sessions:      sessions,
+		retryInterval: retryInterval,
+		log:           log,
+	}
+}
+
+// Watch registers owner for changes to the inventory visible to the given account that could affect rules of the
+// given validation types, starting a watch if none exists for the account's session. If owner was previously
+// registered for a different session, e.g., because its credentials changed, that registration is released.
+func (w *InventoryWatcher) Watch(owner string, account vcenter.Account, validationTypes []string) error {
+	key, err := NewSessionKey(account)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	prev, ok := w.owners[owner]
+	w.owners[owner] = inventoryOwner{key: key, validationTypes: validationTypes}
+	if ok && prev.key != key {
+		w.stopUnreferenced(prev.key)
+	}
+
+	if _, ok := w.watches[key]; !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		w.watches[key] = cancel
+		go w.run(ctx, key, account)
+	}
+	return nil
+}
+
+// Unwatch releases owner's registration, stopping its watch if no other owner is registered for it.
+func (w *InventoryWatcher) Unwatch(owner string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	o, ok := w.owners[owner]
+	delete(w.owners, owner)
+	if ok {
+		w.stopUnreferenced(o.key)
+	}
+}
+
+// Watching reports whether owner is registered for inventory changes.
+func (w *InventoryWatcher) Watching(owner string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, ok := w.owners[owner]
+	return ok
+}
+
+// Close stops all watches, regardless of their owners.
+func (w *InventoryWatcher) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, cancel := range w.watches {
+		cancel()
+	}
+	w.watches = make(map[SessionKey]context.CancelFunc)
+	w.owners = make(map[string]inventoryOwner)
+}
+
+// stopUnreferenced stops the watch for the given key if no owner is registered for it. The caller must hold w.mu.
+func (w *InventoryWatcher) stopUnreferenced(key SessionKey) {
+	for _, o := range w.owners {
+		if o.key == key {
+			return
+		}
+	}
+	if cancel, ok := w.watches[key]; ok {
+		w.log.V(1).Info("stopping unreferenced vCenter inventory watch", "host", key.Host, "username", key.Username)
+		cancel()
+		delete(w.watches, key)
+	}
+}
+
+// ownersOf returns the owners registered for the given key whose rules could be affected by changes to objects of
+// the given types. If objectTypes is nil, all owners registered for the key are returned.
+func (w *InventoryWatcher) ownersOf(key SessionKey, objectTypes []string) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	owners := make([]string, 0)
+	for name, o := range w.owners {
+		if o.key == key && (objectTypes == nil || affectedBy(objectTypes, o.validationTypes)) {
+			owners = append(owners, name)
+		}
+	}
+	return owners
+}
+
+// affectedBy reports whether rules of the given validation types could be affected by changes to objects of the
+// given types
+func affectedBy(objectTypes, validationTypes []string) bool {
+	for _, t := range objectTypes {
+		for _, vt := range WatchedTypeValidationTypes[t] {
+			if slices.Contains(validationTypes, vt) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// run watches the inventory until ctx is canceled, re-establishing the watch whenever it fails
+func (w *InventoryWatcher) run(ctx context.Context, key SessionKey, account vcenter.Account) {
+	reconnect := false
+	for {
+		err := w.watch(ctx, key, account, reconnect)
+		if ctx.Err() != nil {
+			return
+		}
+		w.log.Error(err, "vCenter inventory watch failed", "host", key.Host, "retryInterval", w.retryInterval)
+		reconnect = true
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.retryInterval):
+		}
+	}
+}
+
+// watch waits for updates to the watched properties, notifying the watch's owners affected by each change. If
+// reconnect is true, all owners are also notified once the watch is established, since changes may have been missed.
+func (w *InventoryWatcher) watch(ctx context.Context, key SessionKey, account vcenter.Account, reconnect bool) error {
+	s, err := w.sessions.GetOrCreate(ctx, account, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to get vCenter session")
+	}
+	c := s.GovmomiClient.Client
+
+	cv, err := view.NewManager(c).CreateContainerView(ctx, c.ServiceContent.RootFolder, watchedViewTypes, true)
+	if err != nil {
+		return errors.Wrap(err, "failed to create inventory container view")
+	}
+	defer func() { _ = cv.Destroy(context.Background()) }()
+
+	objects := []types.ObjectSpec{{
+		Obj:  cv.Reference(),
+		Skip: types.NewBool(true),
+		SelectSet: []types.BaseSelectionSpec{
+			&types.TraversalSpec{Type: "ContainerView", Path: "view"},
+		},
+	}}
+	if c.ServiceContent.AuthorizationManager != nil {
+		objects = append(objects, types.ObjectSpec{Obj: *c.ServiceContent.AuthorizationManager})
+	}
+	filter := &property.WaitFilter{
+		CreateFilter: types.CreateFilter{
+			Spec: types.PropertyFilterSpec{ObjectSet: objects, PropSet: watchedProperties},
+		},
+	}
+
+	w.log.V(1).Info("watching vCenter inventory", "host", key.Host, "username", key.Username)
+
+	initial := true
+	return property.WaitForUpdates(ctx, property.DefaultCollector(c), filter, func(updates []types.ObjectUpdate) bool {
+		// the first update reports the current state of every watched object
+		var objectTypes []string
+		if initial {
+			initial = false
+			if !reconnect {
+				return false
+			}
+		} else {
+			objectTypes = make([]string, 0, len(updates))
+			for _, u := range updates {
+				if !slices.Contains(objectTypes, u.Obj.Type) {
+					objectTypes = append(objectTypes, u.Obj.Type)
+				}
+			}
+		}
+		owners := w.ownersOf(key, objectTypes)
+		w.log.V(1).Info("vCenter inventory changed", "host", key.Host, "updates", len(updates), "objectTypes", objectTypes, "owners", owners)
+		if len(owners) > 0 {
+			w.notify(owners)
+		}
+		return false
+	})
+}
@@ -0,0 +1,105 @@
+package vsphere
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/object"
+
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/constants"
+	"github.com/validator-labs/validator-plugin-vsphere/pkg/vcsim"
+)
+
+func TestInventoryWatcher(t *testing.T) {
+	vcSim := vcsim.NewVCSim("admin@vsphere.local", 8474, logr.Logger{})
+	vcSim.Start()
+	defer vcSim.Shutdown()
+
+	sessions := NewSessionManager(0, logr.Discard())
+	defer sessions.Close(context.Background())
+
+	notified := make(chan []string, 10)
+	w := newInventoryWatcher(sessions, time.Second, func(owners []string) { notified <- owners }, logr.Discard())
+	defer w.Close()
+
+	if err := w.Watch("default/vsphere", vcSim.Account, []string{constants.ValidationTypePrivileges}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Watch("default/folders", vcSim.Account, []string{constants.ValidationTypeFolder}); err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, w.Watching("default/vsphere"))
+	assert.Len(t, w.watches, 1)
+
+	// the initial state of the inventory is not reported as a change
+	select {
+	case owners := <-notified:
+		t.Fatalf("unexpected notification for %v", owners)
+	case <-time.After(time.Second):
+	}
+
+	ctx := context.Background()
+	driver, err := NewVCenterDriver(vcSim.Account, vcSim.Options.Datacenter, logr.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	finder, _, err := driver.GetFinderWithDatacenter(ctx, vcSim.Options.Datacenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rename := func(ref object.Common, name string) {
+		task, err := ref.Rename(ctx, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := task.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// virtual machines are not watched
+	vms, err := finder.VirtualMachineList(ctx, "*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rename(vms[0].Common, "VM0_renamed")
+	select {
+	case owners := <-notified:
+		t.Fatalf("unexpected notification for %v after renaming virtual machine", owners)
+	case <-time.After(time.Second):
+	}
+
+	// only the owners whose rules could be affected by a change are notified
+	network, err := finder.Network(ctx, "DC0_DVPG0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rename(object.NewCommon(driver.Client.Client, network.Reference()), "DC0_DVPG0_renamed")
+	select {
+	case owners := <-notified:
+		assert.Equal(t, []string{"default/vsphere"}, owners)
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected notification after renaming port group")
+	}
+
+	cluster, err := driver.GetCluster(ctx, finder, vcSim.Options.Datacenter, vcSim.Options.Cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rename(cluster.Common, "DC0_C0_renamed")
+	select {
+	case owners := <-notified:
+		assert.ElementsMatch(t, []string{"default/vsphere", "default/folders"}, owners)
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected notification after renaming cluster")
+	}
+
+	w.Unwatch("default/vsphere")
+	assert.False(t, w.Watching("default/vsphere"))
+	assert.Len(t, w.watches, 1)
+	w.Unwatch("default/folders")
+	assert.Empty(t, w.watches)
+}